@@ -0,0 +1,23 @@
+// Command fake-airbyte serves the in-memory fake Airbyte API used by the provider tests, for
+// trying the provider out locally without running Airbyte.
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+
+	"github.com/eabrouwer3/terraform-provider-airbyte/internal/fakeairbyte"
+)
+
+func main() {
+	addr := flag.String("addr", "localhost:8000", "address to listen on")
+	version := flag.String("version", fakeairbyte.DefaultVersion, "Airbyte version to report")
+	flag.Parse()
+
+	h := fakeairbyte.NewHandler()
+	h.Version = *version
+
+	log.Printf("fake Airbyte %s listening on http://%s", *version, *addr)
+	log.Fatal(http.ListenAndServe(*addr, h))
+}
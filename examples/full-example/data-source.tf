@@ -20,4 +20,12 @@ data "airbyte_sourcedefinition" "zendesk" {
 
 output "sourcedefinition_zendesk" {
   value = data.airbyte_sourcedefinition.zendesk
-}
\ No newline at end of file
+}
+
+data "airbyte_destination_definition" "postgres" {
+  id = "25c5221d-dce2-4163-ade9-739ef790f503"
+}
+
+output "destination_definition_postgres" {
+  value = data.airbyte_destination_definition.postgres
+}
@@ -52,6 +52,21 @@ output "simple_airbyte_sourcedefinition" {
 #  value = airbyte_sourcedefinition.complex
 #}
 
+resource "airbyte_destination_definition" "simple" {
+  name = "simple"
+  docker_repository = "airbyte/destination-postgres"
+  docker_image_tag = "0.4.0"
+  documentation_url = "https://docs.airbyte.com/integrations/destinations/postgres"
+  job_specific_resource_requirements {
+    job_type = "sync"
+    memory_limit = "2Gi"
+  }
+}
+
+output "simple_airbyte_destination_definition" {
+  value = airbyte_destination_definition.simple
+}
+
 resource "airbyte_source" "simple" {
   sourcedefinition_id = airbyte_sourcedefinition.simple.id
   workspace_id = airbyte_sourcedefinition.simple.id
@@ -67,4 +82,61 @@ resource "airbyte_source" "simple" {
 
 output "simple_airbyte_source" {
   value = airbyte_source.simple
-}
\ No newline at end of file
+}
+resource "airbyte_destination" "simple" {
+  destination_definition_id = airbyte_destination_definition.simple.id
+  workspace_id = airbyte_workspace.simple.id
+  name = "simple_destination"
+  connection_configuration = {
+    host = "localhost"
+    port = "5432"
+    database = "airbyte"
+    schema = "public"
+    username = "airbyte"
+    password = "hunter2"
+  }
+}
+
+output "simple_airbyte_destination" {
+  value = airbyte_destination.simple
+}
+
+resource "airbyte_connection" "simple" {
+  name = "simple_connection"
+  source_id = airbyte_source.simple.id
+  destination_id = airbyte_destination.simple.id
+  namespace_definition = "customformat"
+  namespace_format = "github_$${SOURCE_NAMESPACE}"
+  prefix = "gh_"
+  schedule {
+    schedule_type = "cron"
+    cron_expression = "0 0 12 * * ?"
+    cron_time_zone = "America/Denver"
+  }
+  stream {
+    name = "commits"
+    sync_mode = "incremental"
+    destination_sync_mode = "append_dedup"
+  }
+  stream {
+    name = "issues"
+    sync_mode = "incremental"
+    destination_sync_mode = "append"
+    selected_fields = ["id", "title", "state", "updated_at"]
+  }
+  stream_defaults {
+    selected = false
+  }
+}
+
+output "simple_airbyte_connection" {
+  value = airbyte_connection.simple
+}
+
+data "airbyte_source_schema" "simple" {
+  source_id = airbyte_source.simple.id
+}
+
+output "simple_airbyte_source_streams" {
+  value = [for s in data.airbyte_source_schema.simple.streams : s.name]
+}
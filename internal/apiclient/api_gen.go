@@ -0,0 +1,888 @@
+// Code generated by gen from openapi/config.json; DO NOT EDIT.
+
+package apiclient
+
+import "context"
+
+// actor definition specific resource requirements. if default is set, these are the requirements that should be set for ALL jobs run for this actor definition. it is overriden by the job type specific configurations. if not set, the platform will use defaults. these values will be overriden by configuration at the connection level.
+type ActorDefinitionResourceRequirements struct {
+	Default     *ResourceRequirements  `json:"default,omitempty"`
+	JobSpecific []JobTypeResourceLimit `json:"jobSpecific"`
+}
+
+// describes the available schema (catalog).
+type AirbyteCatalog struct {
+	Streams []AirbyteStreamAndConfiguration `json:"streams"`
+}
+
+// the immutable schema defined by the source
+type AirbyteStream struct {
+	// Path to the field that will be used to determine if a record is new or modified since the last sync. If not provided by the source, the end user will have to specify the comparable themselves.
+	DefaultCursorField []string       `json:"defaultCursorField"`
+	JsonSchema         map[string]any `json:"jsonSchema,omitempty"`
+	// Stream's name.
+	Name string `json:"name"`
+	// Optional Source-defined namespace. Airbyte streams from the same sources should have the same namespace. Currently only used by JDBC destinations to determine what schema to write to.
+	Namespace string `json:"namespace,omitempty"`
+	// If the source defines the cursor field, then any other cursor field inputs will be ignored. If it does not, either the user_provided one is used, or the default one is used as a backup.
+	SourceDefinedCursor *bool `json:"sourceDefinedCursor,omitempty"`
+	// If the source defines the primary key, paths to the fields that will be used as a primary key. If not provided by the source, the end user will have to specify the primary key themselves.
+	SourceDefinedPrimaryKey [][]string `json:"sourceDefinedPrimaryKey"`
+	SupportedSyncModes      []SyncMode `json:"supportedSyncModes"`
+}
+
+// each stream is split in two parts; the immutable schema from source and mutable configuration for destination
+type AirbyteStreamAndConfiguration struct {
+	Config *AirbyteStreamConfiguration `json:"config,omitempty"`
+	Stream *AirbyteStream              `json:"stream,omitempty"`
+}
+
+// the mutable part of the stream to configure the destination
+type AirbyteStreamConfiguration struct {
+	// Alias name to the stream to be used in the destination
+	AliasName string `json:"aliasName,omitempty"`
+	// Path to the field that will be used to determine if a record is new or modified since the last sync. This field is REQUIRED if `sync_mode` is `incremental`. Otherwise it is ignored.
+	CursorField         []string            `json:"cursorField"`
+	DestinationSyncMode DestinationSyncMode `json:"destinationSyncMode"`
+	// Whether field selection should be enabled. If this is true, only the properties in `selectedFields` will be included.
+	FieldSelectionEnabled *bool `json:"fieldSelectionEnabled,omitempty"`
+	// Paths to the fields that will be used as primary key. This field is REQUIRED if `destination_sync_mode` is `*_dedup`. Otherwise it is ignored.
+	PrimaryKey [][]string `json:"primaryKey"`
+	// If this is true, the stream is selected with all of its properties. For new connections, this considers if the stream is suggested or not
+	Selected *bool `json:"selected,omitempty"`
+	// Paths to the fields that will be included in the configured catalog. This must be set if `fieldSelectedEnabled` is set. An empty list indicates that no properties will be included.
+	SelectedFields []SelectedFieldInfo `json:"selectedFields"`
+	// Does the connector suggest that this stream be enabled by default?
+	Suggested *bool    `json:"suggested,omitempty"`
+	SyncMode  SyncMode `json:"syncMode"`
+}
+
+type ConnectionCreate struct {
+	DestinationId string    `json:"destinationId"`
+	Geography     Geography `json:"geography,omitempty"`
+	// Optional name of the connection
+	Name                string                  `json:"name,omitempty"`
+	NamespaceDefinition NamespaceDefinitionType `json:"namespaceDefinition,omitempty"`
+	NamespaceFormat     string                  `json:"namespaceFormat,omitempty"`
+	// Prefix that will be prepended to the name of each stream when it is written to the destination.
+	Prefix       string                  `json:"prefix,omitempty"`
+	ScheduleData *ConnectionScheduleData `json:"scheduleData,omitempty"`
+	ScheduleType ConnectionScheduleType  `json:"scheduleType,omitempty"`
+	SourceId     string                  `json:"sourceId"`
+	Status       ConnectionStatus        `json:"status"`
+	SyncCatalog  *AirbyteCatalog         `json:"syncCatalog,omitempty"`
+}
+
+type ConnectionIdRequestBody struct {
+	ConnectionId string `json:"connectionId"`
+}
+
+type ConnectionRead struct {
+	BreakingChange      bool                    `json:"breakingChange"`
+	ConnectionId        string                  `json:"connectionId"`
+	DestinationId       string                  `json:"destinationId"`
+	Geography           Geography               `json:"geography,omitempty"`
+	Name                string                  `json:"name"`
+	NamespaceDefinition NamespaceDefinitionType `json:"namespaceDefinition,omitempty"`
+	NamespaceFormat     string                  `json:"namespaceFormat,omitempty"`
+	// Prefix that will be prepended to the name of each stream when it is written to the destination.
+	Prefix       string                  `json:"prefix,omitempty"`
+	ScheduleData *ConnectionScheduleData `json:"scheduleData,omitempty"`
+	ScheduleType ConnectionScheduleType  `json:"scheduleType,omitempty"`
+	SourceId     string                  `json:"sourceId"`
+	Status       ConnectionStatus        `json:"status"`
+	SyncCatalog  AirbyteCatalog          `json:"syncCatalog"`
+}
+
+type ConnectionReadList struct {
+	Connections []ConnectionRead `json:"connections"`
+}
+
+// schedule for when the the connection should run, per the schedule type
+type ConnectionScheduleData struct {
+	BasicSchedule *ConnectionScheduleDataBasicSchedule `json:"basicSchedule,omitempty"`
+	Cron          *ConnectionScheduleDataCron          `json:"cron,omitempty"`
+}
+
+type ConnectionScheduleDataBasicSchedule struct {
+	TimeUnit string `json:"timeUnit"`
+	Units    int64  `json:"units"`
+}
+
+type ConnectionScheduleDataCron struct {
+	CronExpression string `json:"cronExpression"`
+	CronTimeZone   string `json:"cronTimeZone"`
+}
+
+// determine how the schedule data should be interpreted
+type ConnectionScheduleType string
+
+const (
+	ConnectionScheduleTypeManual ConnectionScheduleType = "manual"
+	ConnectionScheduleTypeBasic  ConnectionScheduleType = "basic"
+	ConnectionScheduleTypeCron   ConnectionScheduleType = "cron"
+)
+
+// Active means that data is flowing through the connection. Inactive means it is not. Deprecated means the connection is off and cannot be re-activated. the schema field describes the elements of the schema that will be synced.
+type ConnectionStatus string
+
+const (
+	ConnectionStatusActive     ConnectionStatus = "active"
+	ConnectionStatusInactive   ConnectionStatus = "inactive"
+	ConnectionStatusDeprecated ConnectionStatus = "deprecated"
+)
+
+// Used to apply a patch-style update to a connection, which means that null properties remain unchanged
+type ConnectionUpdate struct {
+	ConnectionId string    `json:"connectionId"`
+	Geography    Geography `json:"geography,omitempty"`
+	// Name that will be set to this connection
+	Name                string                  `json:"name,omitempty"`
+	NamespaceDefinition NamespaceDefinitionType `json:"namespaceDefinition,omitempty"`
+	// Used when namespaceDefinition is 'customformat'. If blank then behaves like namespaceDefinition = 'destination'. If "${SOURCE_NAMESPACE}" then behaves like namespaceDefinition = 'source'.
+	NamespaceFormat *string `json:"namespaceFormat,omitempty"`
+	// Prefix that will be prepended to the name of each stream when it is written to the destination.
+	Prefix       *string                 `json:"prefix,omitempty"`
+	ScheduleData *ConnectionScheduleData `json:"scheduleData,omitempty"`
+	ScheduleType ConnectionScheduleType  `json:"scheduleType,omitempty"`
+	Status       ConnectionStatus        `json:"status,omitempty"`
+	SyncCatalog  *AirbyteCatalog         `json:"syncCatalog,omitempty"`
+}
+
+type CustomDestinationDefinitionCreate struct {
+	DestinationDefinition DestinationDefinitionCreate `json:"destinationDefinition"`
+	WorkspaceId           string                      `json:"workspaceId"`
+}
+
+type CustomSourceDefinitionCreate struct {
+	SourceDefinition SourceDefinitionCreate `json:"sourceDefinition"`
+	WorkspaceId      string                 `json:"workspaceId"`
+}
+
+type DeploymentMetadataRead struct {
+	Environment string `json:"environment"`
+	Id          string `json:"id"`
+	Mode        string `json:"mode"`
+	Version     string `json:"version"`
+}
+
+type DestinationCreate struct {
+	ConnectionConfiguration map[string]any `json:"connectionConfiguration"`
+	DestinationDefinitionId string         `json:"destinationDefinitionId"`
+	Name                    string         `json:"name"`
+	WorkspaceId             string         `json:"workspaceId"`
+}
+
+type DestinationDefinitionCreate struct {
+	DockerImageTag       string                               `json:"dockerImageTag"`
+	DockerRepository     string                               `json:"dockerRepository"`
+	DocumentationUrl     string                               `json:"documentationUrl"`
+	Icon                 string                               `json:"icon,omitempty"`
+	Name                 string                               `json:"name"`
+	ResourceRequirements *ActorDefinitionResourceRequirements `json:"resourceRequirements,omitempty"`
+}
+
+type DestinationDefinitionIdRequestBody struct {
+	DestinationDefinitionId string `json:"destinationDefinitionId"`
+}
+
+type DestinationDefinitionIdWithWorkspaceId struct {
+	DestinationDefinitionId string `json:"destinationDefinitionId"`
+	WorkspaceId             string `json:"workspaceId"`
+}
+
+type DestinationDefinitionRead struct {
+	DestinationDefinitionId string                                   `json:"destinationDefinitionId"`
+	DockerImageTag          string                                   `json:"dockerImageTag"`
+	DockerRepository        string                                   `json:"dockerRepository"`
+	DocumentationUrl        string                                   `json:"documentationUrl"`
+	Icon                    string                                   `json:"icon,omitempty"`
+	Name                    string                                   `json:"name"`
+	NormalizationConfig     NormalizationDestinationDefinitionConfig `json:"normalizationConfig"`
+	// The Airbyte Protocol version supported by the connector
+	ProtocolVersion string `json:"protocolVersion,omitempty"`
+	// The date when this connector was first released, in yyyy-mm-dd format.
+	ReleaseDate          string                               `json:"releaseDate,omitempty"`
+	ReleaseStage         ReleaseStage                         `json:"releaseStage,omitempty"`
+	ResourceRequirements *ActorDefinitionResourceRequirements `json:"resourceRequirements,omitempty"`
+	// an optional flag indicating whether DBT is used in the normalization. If the flag value is NULL - DBT is not used.
+	SupportsDbt bool `json:"supportsDbt"`
+}
+
+type DestinationDefinitionReadList struct {
+	DestinationDefinitions []DestinationDefinitionRead `json:"destinationDefinitions"`
+}
+
+type DestinationDefinitionSpecificationRead struct {
+	ConnectionSpecification       map[string]any        `json:"connectionSpecification,omitempty"`
+	DestinationDefinitionId       string                `json:"destinationDefinitionId"`
+	DocumentationUrl              string                `json:"documentationUrl,omitempty"`
+	JobInfo                       SynchronousJobRead    `json:"jobInfo"`
+	SupportedDestinationSyncModes []DestinationSyncMode `json:"supportedDestinationSyncModes"`
+	SupportsDbt                   *bool                 `json:"supportsDbt,omitempty"`
+	SupportsNormalization         *bool                 `json:"supportsNormalization,omitempty"`
+}
+
+type DestinationDefinitionUpdate struct {
+	DestinationDefinitionId string                               `json:"destinationDefinitionId"`
+	DockerImageTag          string                               `json:"dockerImageTag"`
+	ResourceRequirements    *ActorDefinitionResourceRequirements `json:"resourceRequirements,omitempty"`
+}
+
+type DestinationIdRequestBody struct {
+	DestinationId string `json:"destinationId"`
+}
+
+type DestinationRead struct {
+	ConnectionConfiguration map[string]any `json:"connectionConfiguration"`
+	DestinationDefinitionId string         `json:"destinationDefinitionId"`
+	DestinationId           string         `json:"destinationId"`
+	DestinationName         string         `json:"destinationName"`
+	Icon                    string         `json:"icon,omitempty"`
+	Name                    string         `json:"name"`
+	WorkspaceId             string         `json:"workspaceId"`
+}
+
+type DestinationReadList struct {
+	Destinations []DestinationRead `json:"destinations"`
+}
+
+type DestinationSyncMode string
+
+const (
+	DestinationSyncModeAppend      DestinationSyncMode = "append"
+	DestinationSyncModeOverwrite   DestinationSyncMode = "overwrite"
+	DestinationSyncModeAppendDedup DestinationSyncMode = "append_dedup"
+)
+
+type DestinationUpdate struct {
+	ConnectionConfiguration map[string]any `json:"connectionConfiguration"`
+	DestinationId           string         `json:"destinationId"`
+	Name                    string         `json:"name"`
+}
+
+type FailureReason struct {
+	// Human readable failure description for presentation in the Airbyte UI to non-technical users.
+	ExternalMessage string `json:"externalMessage,omitempty"`
+	FailureOrigin   string `json:"failureOrigin,omitempty"`
+	// Human readable failure description for consumption by technical system operators, like Airbyte engineers or OSS users.
+	InternalMessage string `json:"internalMessage,omitempty"`
+	// True if it is known that retrying may succeed, e.g. for a transient failure. False if it is known that a retry will not succeed, e.g. for a configuration issue. If not set, retryable status is not well known.
+	Retryable *bool `json:"retryable,omitempty"`
+	Timestamp int64 `json:"timestamp"`
+}
+
+type Geography string
+
+const (
+	GeographyAuto Geography = "auto"
+	GeographyUs   Geography = "us"
+	GeographyEu   Geography = "eu"
+)
+
+type HealthCheckRead struct {
+	Available bool `json:"available"`
+}
+
+type InvalidInputExceptionInfo struct {
+	ExceptionClassName string                 `json:"exceptionClassName,omitempty"`
+	ExceptionStack     []string               `json:"exceptionStack"`
+	Message            string                 `json:"message"`
+	ValidationErrors   []InvalidInputProperty `json:"validationErrors"`
+}
+
+type InvalidInputProperty struct {
+	InvalidValue string `json:"invalidValue,omitempty"`
+	Message      string `json:"message,omitempty"`
+	PropertyPath string `json:"propertyPath"`
+}
+
+// enum that describes the different types of jobs that the platform runs.
+type JobType string
+
+const (
+	JobTypeGetSpec           JobType = "get_spec"
+	JobTypeCheckConnection   JobType = "check_connection"
+	JobTypeDiscoverSchema    JobType = "discover_schema"
+	JobTypeSync              JobType = "sync"
+	JobTypeResetConnection   JobType = "reset_connection"
+	JobTypeConnectionUpdater JobType = "connection_updater"
+	JobTypeReplicate         JobType = "replicate"
+)
+
+// sets resource requirements for a specific job type for an actor definition. these values override the default, if both are set.
+type JobTypeResourceLimit struct {
+	JobType              JobType              `json:"jobType"`
+	ResourceRequirements ResourceRequirements `json:"resourceRequirements"`
+}
+
+type KnownExceptionInfo struct {
+	ExceptionClassName          string   `json:"exceptionClassName,omitempty"`
+	ExceptionStack              []string `json:"exceptionStack"`
+	Message                     string   `json:"message"`
+	RootCauseExceptionClassName string   `json:"rootCauseExceptionClassName,omitempty"`
+	RootCauseExceptionStack     []string `json:"rootCauseExceptionStack"`
+}
+
+// Method used for computing final namespace in destination
+type NamespaceDefinitionType string
+
+const (
+	NamespaceDefinitionTypeSource       NamespaceDefinitionType = "source"
+	NamespaceDefinitionTypeDestination  NamespaceDefinitionType = "destination"
+	NamespaceDefinitionTypeCustomformat NamespaceDefinitionType = "customformat"
+)
+
+// describes a normalization config for destination definition
+type NormalizationDestinationDefinitionConfig struct {
+	// a field indicating the type of integration dialect to use for normalization.
+	NormalizationIntegrationType string `json:"normalizationIntegrationType,omitempty"`
+	// a field indicating the name of the repository to be used for normalization. If the value of the flag is NULL - normalization is not used.
+	NormalizationRepository string `json:"normalizationRepository,omitempty"`
+	// a field indicating the tag of the docker repository to be used for normalization.
+	NormalizationTag string `json:"normalizationTag,omitempty"`
+	// whether the destination definition supports normalization.
+	Supported bool `json:"supported"`
+}
+
+type NotFoundKnownExceptionInfo struct {
+	ExceptionClassName          string   `json:"exceptionClassName,omitempty"`
+	ExceptionStack              []string `json:"exceptionStack"`
+	Id                          string   `json:"id,omitempty"`
+	Message                     string   `json:"message"`
+	RootCauseExceptionClassName string   `json:"rootCauseExceptionClassName,omitempty"`
+	RootCauseExceptionStack     []string `json:"rootCauseExceptionStack"`
+}
+
+type Notification struct {
+	NotificationType   NotificationType                `json:"notificationType"`
+	SendOnFailure      bool                            `json:"sendOnFailure"`
+	SendOnSuccess      bool                            `json:"sendOnSuccess"`
+	SlackConfiguration *SlackNotificationConfiguration `json:"slackConfiguration,omitempty"`
+}
+
+type NotificationType string
+
+const (
+	NotificationTypeSlack      NotificationType = "slack"
+	NotificationTypeCustomerio NotificationType = "customerio"
+)
+
+type ReleaseStage string
+
+const (
+	ReleaseStageAlpha              ReleaseStage = "alpha"
+	ReleaseStageBeta               ReleaseStage = "beta"
+	ReleaseStageGenerallyAvailable ReleaseStage = "generally_available"
+	ReleaseStageCustom             ReleaseStage = "custom"
+)
+
+// optional resource requirements to run workers (blank for unbounded allocations)
+type ResourceRequirements struct {
+	CpuLimit      string `json:"cpu_limit,omitempty"`
+	CpuRequest    string `json:"cpu_request,omitempty"`
+	MemoryLimit   string `json:"memory_limit,omitempty"`
+	MemoryRequest string `json:"memory_request,omitempty"`
+}
+
+// Path to a field/column/property in a stream to be selected. For example, if the field to be selected is a database column called "foo", this will be ["foo"]. Use multiple path elements for nested schemas.
+type SelectedFieldInfo struct {
+	FieldPath []string `json:"fieldPath"`
+}
+
+type SlackNotificationConfiguration struct {
+	Webhook string `json:"webhook"`
+}
+
+type SlugRequestBody struct {
+	Slug string `json:"slug"`
+}
+
+type SourceCreate struct {
+	ConnectionConfiguration map[string]any `json:"connectionConfiguration"`
+	Name                    string         `json:"name"`
+	SourceDefinitionId      string         `json:"sourceDefinitionId"`
+	WorkspaceId             string         `json:"workspaceId"`
+}
+
+type SourceDefinitionCreate struct {
+	DockerImageTag       string                               `json:"dockerImageTag"`
+	DockerRepository     string                               `json:"dockerRepository"`
+	DocumentationUrl     string                               `json:"documentationUrl"`
+	Icon                 string                               `json:"icon,omitempty"`
+	Name                 string                               `json:"name"`
+	ResourceRequirements *ActorDefinitionResourceRequirements `json:"resourceRequirements,omitempty"`
+}
+
+type SourceDefinitionIdRequestBody struct {
+	SourceDefinitionId string `json:"sourceDefinitionId"`
+}
+
+type SourceDefinitionIdWithWorkspaceId struct {
+	SourceDefinitionId string `json:"sourceDefinitionId"`
+	WorkspaceId        string `json:"workspaceId"`
+}
+
+type SourceDefinitionRead struct {
+	DockerImageTag   string `json:"dockerImageTag"`
+	DockerRepository string `json:"dockerRepository"`
+	DocumentationUrl string `json:"documentationUrl,omitempty"`
+	Icon             string `json:"icon,omitempty"`
+	Name             string `json:"name"`
+	// The Airbyte Protocol version supported by the connector
+	ProtocolVersion string `json:"protocolVersion,omitempty"`
+	// The date when this connector was first released, in yyyy-mm-dd format.
+	ReleaseDate          string                               `json:"releaseDate,omitempty"`
+	ReleaseStage         ReleaseStage                         `json:"releaseStage,omitempty"`
+	ResourceRequirements *ActorDefinitionResourceRequirements `json:"resourceRequirements,omitempty"`
+	SourceDefinitionId   string                               `json:"sourceDefinitionId"`
+	SourceType           SourceType                           `json:"sourceType,omitempty"`
+}
+
+type SourceDefinitionReadList struct {
+	SourceDefinitions []SourceDefinitionRead `json:"sourceDefinitions"`
+}
+
+type SourceDefinitionSpecificationRead struct {
+	ConnectionSpecification map[string]any     `json:"connectionSpecification,omitempty"`
+	DocumentationUrl        string             `json:"documentationUrl,omitempty"`
+	JobInfo                 SynchronousJobRead `json:"jobInfo"`
+	SourceDefinitionId      string             `json:"sourceDefinitionId"`
+}
+
+type SourceDefinitionUpdate struct {
+	DockerImageTag       string                               `json:"dockerImageTag"`
+	ResourceRequirements *ActorDefinitionResourceRequirements `json:"resourceRequirements,omitempty"`
+	SourceDefinitionId   string                               `json:"sourceDefinitionId"`
+}
+
+// Returns the results of a discover catalog job. If the job was not successful, the catalog field will not be present. jobInfo will aways be present and its status be used to determine if the job was successful or not.
+type SourceDiscoverSchemaRead struct {
+	BreakingChange *bool              `json:"breakingChange,omitempty"`
+	Catalog        *AirbyteCatalog    `json:"catalog,omitempty"`
+	CatalogId      string             `json:"catalogId,omitempty"`
+	JobInfo        SynchronousJobRead `json:"jobInfo"`
+}
+
+type SourceDiscoverSchemaRequestBody struct {
+	ConnectionId       string `json:"connectionId,omitempty"`
+	DisableCache       *bool  `json:"disable_cache,omitempty"`
+	NotifySchemaChange *bool  `json:"notifySchemaChange,omitempty"`
+	SourceId           string `json:"sourceId"`
+}
+
+type SourceIdRequestBody struct {
+	SourceId string `json:"sourceId"`
+}
+
+type SourceRead struct {
+	ConnectionConfiguration map[string]any `json:"connectionConfiguration"`
+	Icon                    string         `json:"icon,omitempty"`
+	Name                    string         `json:"name"`
+	SourceDefinitionId      string         `json:"sourceDefinitionId"`
+	SourceId                string         `json:"sourceId"`
+	SourceName              string         `json:"sourceName"`
+	WorkspaceId             string         `json:"workspaceId"`
+}
+
+type SourceReadList struct {
+	Sources []SourceRead `json:"sources"`
+}
+
+type SourceType string
+
+const (
+	SourceTypeApi      SourceType = "api"
+	SourceTypeFile     SourceType = "file"
+	SourceTypeDatabase SourceType = "database"
+	SourceTypeCustom   SourceType = "custom"
+)
+
+type SourceUpdate struct {
+	ConnectionConfiguration map[string]any `json:"connectionConfiguration"`
+	Name                    string         `json:"name"`
+	SourceId                string         `json:"sourceId"`
+}
+
+type SyncMode string
+
+const (
+	SyncModeFullRefresh SyncMode = "full_refresh"
+	SyncModeIncremental SyncMode = "incremental"
+)
+
+type SynchronousJobRead struct {
+	ConfigType    string         `json:"configType"`
+	CreatedAt     int64          `json:"createdAt"`
+	EndedAt       int64          `json:"endedAt"`
+	FailureReason *FailureReason `json:"failureReason,omitempty"`
+	Id            string         `json:"id"`
+	Succeeded     bool           `json:"succeeded"`
+}
+
+type WorkspaceCreate struct {
+	AnonymousDataCollection *bool          `json:"anonymousDataCollection,omitempty"`
+	DefaultGeography        Geography      `json:"defaultGeography,omitempty"`
+	DisplaySetupWizard      *bool          `json:"displaySetupWizard,omitempty"`
+	Email                   string         `json:"email,omitempty"`
+	Name                    string         `json:"name"`
+	News                    *bool          `json:"news,omitempty"`
+	Notifications           []Notification `json:"notifications"`
+	SecurityUpdates         *bool          `json:"securityUpdates,omitempty"`
+}
+
+type WorkspaceIdRequestBody struct {
+	WorkspaceId string `json:"workspaceId"`
+}
+
+type WorkspaceRead struct {
+	AnonymousDataCollection *bool          `json:"anonymousDataCollection,omitempty"`
+	CustomerId              string         `json:"customerId"`
+	DefaultGeography        Geography      `json:"defaultGeography,omitempty"`
+	DisplaySetupWizard      *bool          `json:"displaySetupWizard,omitempty"`
+	Email                   string         `json:"email,omitempty"`
+	FeedbackDone            *bool          `json:"feedbackDone,omitempty"`
+	FirstCompletedSync      *bool          `json:"firstCompletedSync,omitempty"`
+	InitialSetupComplete    bool           `json:"initialSetupComplete"`
+	Name                    string         `json:"name"`
+	News                    *bool          `json:"news,omitempty"`
+	Notifications           []Notification `json:"notifications"`
+	SecurityUpdates         *bool          `json:"securityUpdates,omitempty"`
+	Slug                    string         `json:"slug"`
+	Tombstone               *bool          `json:"tombstone,omitempty"`
+	WorkspaceId             string         `json:"workspaceId"`
+}
+
+type WorkspaceReadList struct {
+	Workspaces []WorkspaceRead `json:"workspaces"`
+}
+
+type WorkspaceUpdate struct {
+	AnonymousDataCollection *bool          `json:"anonymousDataCollection,omitempty"`
+	DefaultGeography        Geography      `json:"defaultGeography,omitempty"`
+	DisplaySetupWizard      *bool          `json:"displaySetupWizard,omitempty"`
+	Email                   *string        `json:"email,omitempty"`
+	InitialSetupComplete    *bool          `json:"initialSetupComplete,omitempty"`
+	News                    *bool          `json:"news,omitempty"`
+	Notifications           []Notification `json:"notifications"`
+	SecurityUpdates         *bool          `json:"securityUpdates,omitempty"`
+	WorkspaceId             string         `json:"workspaceId"`
+}
+
+// CreateConnection calls POST /v1/connections/create: Create a connection between a source and a destination.
+func (c *ApiClient) CreateConnection(ctx context.Context, body ConnectionCreate) (*ConnectionRead, error) {
+	out := &ConnectionRead{}
+	if err := c.call(ctx, "POST", "connections/create", body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeleteConnection calls POST /v1/connections/delete: Delete a connection.
+func (c *ApiClient) DeleteConnection(ctx context.Context, body ConnectionIdRequestBody) error {
+	return c.call(ctx, "POST", "connections/delete", body, nil)
+}
+
+// GetConnection calls POST /v1/connections/get: Get a connection.
+func (c *ApiClient) GetConnection(ctx context.Context, body ConnectionIdRequestBody) (*ConnectionRead, error) {
+	out := &ConnectionRead{}
+	if err := c.call(ctx, "POST", "connections/get", body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListConnectionsForWorkspace calls POST /v1/connections/list: Returns all connections for a workspace.
+func (c *ApiClient) ListConnectionsForWorkspace(ctx context.Context, body WorkspaceIdRequestBody) (*ConnectionReadList, error) {
+	out := &ConnectionReadList{}
+	if err := c.call(ctx, "POST", "connections/list", body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpdateConnection calls POST /v1/connections/update: Update a connection.
+func (c *ApiClient) UpdateConnection(ctx context.Context, body ConnectionUpdate) (*ConnectionRead, error) {
+	out := &ConnectionRead{}
+	if err := c.call(ctx, "POST", "connections/update", body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetDeploymentMetadata calls POST /v1/deployment/metadata: Provide details about the current Airbyte deployment.
+func (c *ApiClient) GetDeploymentMetadata(ctx context.Context) (*DeploymentMetadataRead, error) {
+	out := &DeploymentMetadataRead{}
+	if err := c.call(ctx, "POST", "deployment/metadata", nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetDestinationDefinitionSpecification calls POST /v1/destination_definition_specifications/get: Get specification for a destinationDefinition.
+func (c *ApiClient) GetDestinationDefinitionSpecification(ctx context.Context, body DestinationDefinitionIdWithWorkspaceId) (*DestinationDefinitionSpecificationRead, error) {
+	out := &DestinationDefinitionSpecificationRead{}
+	if err := c.call(ctx, "POST", "destination_definition_specifications/get", body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateDestinationDefinition calls POST /v1/destination_definitions/create: Creates a destinationsDefinition.
+func (c *ApiClient) CreateDestinationDefinition(ctx context.Context, body DestinationDefinitionCreate) (*DestinationDefinitionRead, error) {
+	out := &DestinationDefinitionRead{}
+	if err := c.call(ctx, "POST", "destination_definitions/create", body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateCustomDestinationDefinition calls POST /v1/destination_definitions/create_custom: Creates a custom destinationDefinition for the given workspace.
+func (c *ApiClient) CreateCustomDestinationDefinition(ctx context.Context, body CustomDestinationDefinitionCreate) (*DestinationDefinitionRead, error) {
+	out := &DestinationDefinitionRead{}
+	if err := c.call(ctx, "POST", "destination_definitions/create_custom", body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeleteDestinationDefinition calls POST /v1/destination_definitions/delete: Delete a destination definition.
+func (c *ApiClient) DeleteDestinationDefinition(ctx context.Context, body DestinationDefinitionIdRequestBody) error {
+	return c.call(ctx, "POST", "destination_definitions/delete", body, nil)
+}
+
+// GetDestinationDefinition calls POST /v1/destination_definitions/get: Get destinationDefinition.
+func (c *ApiClient) GetDestinationDefinition(ctx context.Context, body DestinationDefinitionIdRequestBody) (*DestinationDefinitionRead, error) {
+	out := &DestinationDefinitionRead{}
+	if err := c.call(ctx, "POST", "destination_definitions/get", body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListDestinationDefinitions calls POST /v1/destination_definitions/list: List all the destinationDefinitions the current Airbyte deployment is configured to use.
+func (c *ApiClient) ListDestinationDefinitions(ctx context.Context) (*DestinationDefinitionReadList, error) {
+	out := &DestinationDefinitionReadList{}
+	if err := c.call(ctx, "POST", "destination_definitions/list", nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpdateDestinationDefinition calls POST /v1/destination_definitions/update: Update destinationDefinition.
+func (c *ApiClient) UpdateDestinationDefinition(ctx context.Context, body DestinationDefinitionUpdate) (*DestinationDefinitionRead, error) {
+	out := &DestinationDefinitionRead{}
+	if err := c.call(ctx, "POST", "destination_definitions/update", body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateDestination calls POST /v1/destinations/create: Create a destination.
+func (c *ApiClient) CreateDestination(ctx context.Context, body DestinationCreate) (*DestinationRead, error) {
+	out := &DestinationRead{}
+	if err := c.call(ctx, "POST", "destinations/create", body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeleteDestination calls POST /v1/destinations/delete: Delete the destination.
+func (c *ApiClient) DeleteDestination(ctx context.Context, body DestinationIdRequestBody) error {
+	return c.call(ctx, "POST", "destinations/delete", body, nil)
+}
+
+// GetDestination calls POST /v1/destinations/get: Get configured destination.
+func (c *ApiClient) GetDestination(ctx context.Context, body DestinationIdRequestBody) (*DestinationRead, error) {
+	out := &DestinationRead{}
+	if err := c.call(ctx, "POST", "destinations/get", body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListDestinationsForWorkspace calls POST /v1/destinations/list: List configured destinations for a workspace.
+func (c *ApiClient) ListDestinationsForWorkspace(ctx context.Context, body WorkspaceIdRequestBody) (*DestinationReadList, error) {
+	out := &DestinationReadList{}
+	if err := c.call(ctx, "POST", "destinations/list", body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpdateDestination calls POST /v1/destinations/update: Update a destination.
+func (c *ApiClient) UpdateDestination(ctx context.Context, body DestinationUpdate) (*DestinationRead, error) {
+	out := &DestinationRead{}
+	if err := c.call(ctx, "POST", "destinations/update", body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetHealthCheck calls GET /v1/health: Health Check.
+func (c *ApiClient) GetHealthCheck(ctx context.Context) (*HealthCheckRead, error) {
+	out := &HealthCheckRead{}
+	if err := c.call(ctx, "GET", "health", nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetSourceDefinitionSpecification calls POST /v1/source_definition_specifications/get: Get specification for a SourceDefinition.
+func (c *ApiClient) GetSourceDefinitionSpecification(ctx context.Context, body SourceDefinitionIdWithWorkspaceId) (*SourceDefinitionSpecificationRead, error) {
+	out := &SourceDefinitionSpecificationRead{}
+	if err := c.call(ctx, "POST", "source_definition_specifications/get", body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateSourceDefinition calls POST /v1/source_definitions/create: Creates a sourceDefinition.
+func (c *ApiClient) CreateSourceDefinition(ctx context.Context, body SourceDefinitionCreate) (*SourceDefinitionRead, error) {
+	out := &SourceDefinitionRead{}
+	if err := c.call(ctx, "POST", "source_definitions/create", body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateCustomSourceDefinition calls POST /v1/source_definitions/create_custom: Creates a custom sourceDefinition for the given workspace.
+func (c *ApiClient) CreateCustomSourceDefinition(ctx context.Context, body CustomSourceDefinitionCreate) (*SourceDefinitionRead, error) {
+	out := &SourceDefinitionRead{}
+	if err := c.call(ctx, "POST", "source_definitions/create_custom", body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeleteSourceDefinition calls POST /v1/source_definitions/delete: Delete a source definition.
+func (c *ApiClient) DeleteSourceDefinition(ctx context.Context, body SourceDefinitionIdRequestBody) error {
+	return c.call(ctx, "POST", "source_definitions/delete", body, nil)
+}
+
+// GetSourceDefinition calls POST /v1/source_definitions/get: Get source.
+func (c *ApiClient) GetSourceDefinition(ctx context.Context, body SourceDefinitionIdRequestBody) (*SourceDefinitionRead, error) {
+	out := &SourceDefinitionRead{}
+	if err := c.call(ctx, "POST", "source_definitions/get", body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListSourceDefinitions calls POST /v1/source_definitions/list: List all the sourceDefinitions the current Airbyte deployment is configured to use.
+func (c *ApiClient) ListSourceDefinitions(ctx context.Context) (*SourceDefinitionReadList, error) {
+	out := &SourceDefinitionReadList{}
+	if err := c.call(ctx, "POST", "source_definitions/list", nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpdateSourceDefinition calls POST /v1/source_definitions/update: Update a sourceDefinition.
+func (c *ApiClient) UpdateSourceDefinition(ctx context.Context, body SourceDefinitionUpdate) (*SourceDefinitionRead, error) {
+	out := &SourceDefinitionRead{}
+	if err := c.call(ctx, "POST", "source_definitions/update", body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateSource calls POST /v1/sources/create: Create a source.
+func (c *ApiClient) CreateSource(ctx context.Context, body SourceCreate) (*SourceRead, error) {
+	out := &SourceRead{}
+	if err := c.call(ctx, "POST", "sources/create", body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeleteSource calls POST /v1/sources/delete: Delete a source.
+func (c *ApiClient) DeleteSource(ctx context.Context, body SourceIdRequestBody) error {
+	return c.call(ctx, "POST", "sources/delete", body, nil)
+}
+
+// DiscoverSchemaForSource calls POST /v1/sources/discover_schema: Discover the schema catalog of the source.
+func (c *ApiClient) DiscoverSchemaForSource(ctx context.Context, body SourceDiscoverSchemaRequestBody) (*SourceDiscoverSchemaRead, error) {
+	out := &SourceDiscoverSchemaRead{}
+	if err := c.call(ctx, "POST", "sources/discover_schema", body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetSource calls POST /v1/sources/get: Get source.
+func (c *ApiClient) GetSource(ctx context.Context, body SourceIdRequestBody) (*SourceRead, error) {
+	out := &SourceRead{}
+	if err := c.call(ctx, "POST", "sources/get", body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListSourcesForWorkspace calls POST /v1/sources/list: List sources for workspace.
+func (c *ApiClient) ListSourcesForWorkspace(ctx context.Context, body WorkspaceIdRequestBody) (*SourceReadList, error) {
+	out := &SourceReadList{}
+	if err := c.call(ctx, "POST", "sources/list", body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpdateSource calls POST /v1/sources/update: Update a source.
+func (c *ApiClient) UpdateSource(ctx context.Context, body SourceUpdate) (*SourceRead, error) {
+	out := &SourceRead{}
+	if err := c.call(ctx, "POST", "sources/update", body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateWorkspace calls POST /v1/workspaces/create: Creates a workspace.
+func (c *ApiClient) CreateWorkspace(ctx context.Context, body WorkspaceCreate) (*WorkspaceRead, error) {
+	out := &WorkspaceRead{}
+	if err := c.call(ctx, "POST", "workspaces/create", body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeleteWorkspace calls POST /v1/workspaces/delete: Deletes a workspace.
+func (c *ApiClient) DeleteWorkspace(ctx context.Context, body WorkspaceIdRequestBody) error {
+	return c.call(ctx, "POST", "workspaces/delete", body, nil)
+}
+
+// GetWorkspace calls POST /v1/workspaces/get: Find workspace by ID.
+func (c *ApiClient) GetWorkspace(ctx context.Context, body WorkspaceIdRequestBody) (*WorkspaceRead, error) {
+	out := &WorkspaceRead{}
+	if err := c.call(ctx, "POST", "workspaces/get", body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetWorkspaceBySlug calls POST /v1/workspaces/get_by_slug: Find workspace by slug.
+func (c *ApiClient) GetWorkspaceBySlug(ctx context.Context, body SlugRequestBody) (*WorkspaceRead, error) {
+	out := &WorkspaceRead{}
+	if err := c.call(ctx, "POST", "workspaces/get_by_slug", body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListWorkspaces calls POST /v1/workspaces/list: List all workspaces registered in the current Airbyte deployment.
+func (c *ApiClient) ListWorkspaces(ctx context.Context) (*WorkspaceReadList, error) {
+	out := &WorkspaceReadList{}
+	if err := c.call(ctx, "POST", "workspaces/list", nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpdateWorkspace calls POST /v1/workspaces/update: Update workspace state.
+func (c *ApiClient) UpdateWorkspace(ctx context.Context, body WorkspaceUpdate) (*WorkspaceRead, error) {
+	out := &WorkspaceRead{}
+	if err := c.call(ctx, "POST", "workspaces/update", body, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,195 @@
+package apiclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// tokenExpiryDelta is how long before its expiry a token is considered stale, so that a
+// request started right before the expiry doesn't reach the server with a dead token.
+const tokenExpiryDelta = 30 * time.Second
+
+type Token struct {
+	AccessToken string
+	Expiry      time.Time
+}
+
+func (t *Token) valid() bool {
+	if t == nil || t.AccessToken == "" {
+		return false
+	}
+	if t.Expiry.IsZero() {
+		return true
+	}
+	return time.Now().Add(tokenExpiryDelta).Before(t.Expiry)
+}
+
+// TokenSource supplies the bearer tokens sent with every API request.
+type TokenSource interface {
+	Token(ctx context.Context) (*Token, error)
+}
+
+type StaticTokenSource struct {
+	AccessToken string
+}
+
+func (s *StaticTokenSource) Token(_ context.Context) (*Token, error) {
+	return &Token{AccessToken: s.AccessToken}, nil
+}
+
+// ClientCredentialsTokenSource fetches tokens with the OAuth2 client credentials grant. It works
+// against Airbyte Cloud and against the Keycloak realm of Airbyte Self-Managed Enterprise.
+type ClientCredentialsTokenSource struct {
+	HTTPClient   *http.Client
+	TokenURL     string
+	ClientId     string
+	ClientSecret string
+}
+
+type tokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	TokenType        string `json:"token_type"`
+	ExpiresIn        int64  `json:"expires_in"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+func (s *ClientCredentialsTokenSource) Token(ctx context.Context) (*Token, error) {
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+	form.Set("client_id", s.ClientId)
+	form.Set("client_secret", s.ClientSecret)
+
+	req, err := http.NewRequestWithContext(ctx, "POST", s.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	httpClient := s.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch token from %s: %w", s.TokenURL, err)
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	tr := tokenResponse{}
+	if err := json.Unmarshal(body, &tr); err != nil && res.StatusCode == http.StatusOK {
+		return nil, fmt.Errorf("unable to parse token response from %s: %w", s.TokenURL, err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		if tr.Error != "" {
+			return nil, fmt.Errorf("authentication failed: token endpoint returned %s: %s", tr.Error, tr.ErrorDescription)
+		}
+		return nil, fmt.Errorf("authentication failed: token endpoint returned status %d", res.StatusCode)
+	}
+	if tr.AccessToken == "" {
+		return nil, fmt.Errorf("token response from %s did not contain an access_token", s.TokenURL)
+	}
+
+	token := &Token{AccessToken: tr.AccessToken}
+	if tr.ExpiresIn > 0 {
+		token.Expiry = time.Now().Add(time.Duration(tr.ExpiresIn) * time.Second)
+	}
+
+	return token, nil
+}
+
+// CachingTokenSource hands out the token of the wrapped source until it is about to expire, and
+// only then asks for a new one.
+type CachingTokenSource struct {
+	mu     sync.Mutex
+	source TokenSource
+	token  *Token
+}
+
+func NewCachingTokenSource(source TokenSource) *CachingTokenSource {
+	return &CachingTokenSource{source: source}
+}
+
+func (s *CachingTokenSource) Token(ctx context.Context) (*Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token.valid() {
+		return s.token, nil
+	}
+
+	token, err := s.source.Token(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.token = token
+
+	return token, nil
+}
+
+// CredentialProcessTokenSource runs an external command, in the spirit of the AWS CLI's
+// credential_process, and reads a token from its standard output. The command must print a JSON
+// object such as {"access_token": "...", "expiration": "2006-01-02T15:04:05Z"}; expiration is
+// optional and, when omitted, the token is used for the rest of the run.
+type CredentialProcessTokenSource struct {
+	Command string
+}
+
+type credentialProcessOutput struct {
+	AccessToken string `json:"access_token"`
+	Expiration  string `json:"expiration"`
+}
+
+func (s *CredentialProcessTokenSource) Token(ctx context.Context) (*Token, error) {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", s.Command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", s.Command)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("credential_process failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	out := credentialProcessOutput{}
+	if err := json.Unmarshal(stdout.Bytes(), &out); err != nil {
+		return nil, fmt.Errorf("unable to parse credential_process output as JSON: %w", err)
+	}
+	if out.AccessToken == "" {
+		return nil, fmt.Errorf("credential_process output did not contain an access_token")
+	}
+
+	token := &Token{AccessToken: out.AccessToken}
+	if out.Expiration != "" {
+		expiry, err := time.Parse(time.RFC3339, out.Expiration)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse credential_process expiration %q: %w", out.Expiration, err)
+		}
+		token.Expiry = expiry
+	}
+
+	return token, nil
+}
@@ -0,0 +1,131 @@
+package apiclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func newFakeTokenServer(t *testing.T, expiresIn int, issued *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatal(err)
+		}
+		if r.Form.Get("grant_type") != "client_credentials" || r.Form.Get("client_secret") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"error":"invalid_client","error_description":"bad secret"}`)
+			return
+		}
+		n := atomic.AddInt32(issued, 1)
+		fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"Bearer","expires_in":%d}`, n, expiresIn)
+	}))
+}
+
+func TestCachingTokenSource_reusesValidToken(t *testing.T) {
+	var issued int32
+	srv := newFakeTokenServer(t, 3600, &issued)
+	defer srv.Close()
+
+	ts := NewCachingTokenSource(&ClientCredentialsTokenSource{TokenURL: srv.URL, ClientId: "id", ClientSecret: "secret"})
+
+	for i := 0; i < 3; i++ {
+		tok, err := ts.Token(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tok.AccessToken != "token-1" {
+			t.Fatalf("expected cached token-1, got %s", tok.AccessToken)
+		}
+	}
+	if issued != 1 {
+		t.Fatalf("expected 1 token request, got %d", issued)
+	}
+}
+
+func TestCachingTokenSource_refreshesBeforeExpiry(t *testing.T) {
+	var issued int32
+	// Tokens that live for less than tokenExpiryDelta are always considered stale.
+	srv := newFakeTokenServer(t, 10, &issued)
+	defer srv.Close()
+
+	ts := NewCachingTokenSource(&ClientCredentialsTokenSource{TokenURL: srv.URL, ClientId: "id", ClientSecret: "secret"})
+
+	first, err := ts.Token(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := ts.Token(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.AccessToken == second.AccessToken {
+		t.Fatalf("expected token to be refreshed, got %s twice", first.AccessToken)
+	}
+}
+
+func TestClientCredentialsTokenSource_badCredentials(t *testing.T) {
+	var issued int32
+	srv := newFakeTokenServer(t, 3600, &issued)
+	defer srv.Close()
+
+	ts := &ClientCredentialsTokenSource{TokenURL: srv.URL, ClientId: "id", ClientSecret: "wrong"}
+	if _, err := ts.Token(context.Background()); err == nil {
+		t.Fatal("expected an error for bad client credentials")
+	}
+}
+
+func TestDoRequest_sendsBearerToken(t *testing.T) {
+	var issued int32
+	tokenSrv := newFakeTokenServer(t, 3600, &issued)
+	defer tokenSrv.Close()
+
+	apiSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token-1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `{"workspaceId":"abc","name":"test"}`)
+	}))
+	defer apiSrv.Close()
+
+	c := &ApiClient{
+		HostURL:     apiSrv.URL,
+		HTTPClient:  apiSrv.Client(),
+		TokenSource: NewCachingTokenSource(&ClientCredentialsTokenSource{TokenURL: tokenSrv.URL, ClientId: "id", ClientSecret: "secret"}),
+	}
+
+	w, err := c.GetWorkspaceById(context.Background(), "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w.WorkspaceId != "abc" {
+		t.Fatalf("unexpected workspace id %s", w.WorkspaceId)
+	}
+}
+
+func TestCredentialProcessTokenSource(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a POSIX shell")
+	}
+
+	ts := &CredentialProcessTokenSource{
+		Command: `echo '{"access_token":"from-helper","expiration":"2099-01-01T00:00:00Z"}'`,
+	}
+	tok, err := ts.Token(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tok.AccessToken != "from-helper" || tok.Expiry.Year() != 2099 {
+		t.Fatalf("unexpected token %+v", tok)
+	}
+
+	ts = &CredentialProcessTokenSource{Command: `echo oops >&2; exit 3`}
+	if _, err := ts.Token(context.Background()); err == nil || !strings.Contains(err.Error(), "oops") {
+		t.Fatalf("expected the helper's stderr in the error, got %v", err)
+	}
+}
@@ -0,0 +1,264 @@
+package apiclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// ReadCache answers single-object reads from the list endpoints, so refreshing many objects costs
+// one request per list instead of one per object. It lives for a single provider run; writes
+// through the ApiClient invalidate the entries they touch, and misses fall back to a normal read.
+type ReadCache struct {
+	mu sync.Mutex
+
+	// fills are the list requests made so far, keyed by list (and workspace for per-workspace lists).
+	fills map[string]*cacheFill
+	// written are the ids of objects changed through the client, which the cache no longer answers for.
+	written map[string]bool
+
+	workspaces             map[string]WorkspaceRead
+	sourceDefinitions      map[string]SourceDefinitionRead
+	destinationDefinitions map[string]DestinationDefinitionRead
+	sources                map[string]SourceRead
+	destinations           map[string]DestinationRead
+}
+
+// cacheFill is a list request filling the cache. Concurrent reads wait for the same fill, and done
+// is closed once it has finished, whether it succeeded or not.
+type cacheFill struct {
+	done chan struct{}
+}
+
+func NewReadCache() *ReadCache {
+	return &ReadCache{
+		fills:                  make(map[string]*cacheFill),
+		written:                make(map[string]bool),
+		workspaces:             make(map[string]WorkspaceRead),
+		sourceDefinitions:      make(map[string]SourceDefinitionRead),
+		destinationDefinitions: make(map[string]DestinationDefinitionRead),
+		sources:                make(map[string]SourceRead),
+		destinations:           make(map[string]DestinationRead),
+	}
+}
+
+// fill runs list once per key, without holding the lock while it waits for the server. Other reads
+// of the same key wait for it, or give up when their own ctx is done. A failed fill is remembered
+// like a successful one, so that a list the credentials may not use (e.g. a 403 for non-admin keys)
+// isn't requested again on every read; only fills cut short by their caller's ctx are retried.
+func (rc *ReadCache) fill(ctx context.Context, key string, list func() error) {
+	rc.mu.Lock()
+	f, ok := rc.fills[key]
+	if ok {
+		rc.mu.Unlock()
+
+		select {
+		case <-f.done:
+		case <-ctx.Done():
+		}
+		return
+	}
+	f = &cacheFill{done: make(chan struct{})}
+	rc.fills[key] = f
+	rc.mu.Unlock()
+
+	defer close(f.done)
+
+	err := list()
+	if err == nil {
+		return
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		rc.mu.Lock()
+		delete(rc.fills, key)
+		rc.mu.Unlock()
+	}
+	tflog.SubsystemWarn(WithLogSubsystem(ctx), LogSubsystem, "Unable to fill the read cache, falling back to single reads", map[string]any{
+		"kind":  key,
+		"error": err.Error(),
+	})
+}
+
+// addCached stores listed objects by id, except the ones written since the list was requested.
+func addCached[T any](rc *ReadCache, objects map[string]T, list []T, id func(T) string) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	for _, o := range list {
+		if !rc.written[id(o)] {
+			objects[id(o)] = o
+		}
+	}
+}
+
+// lookupCached returns a deep copy of a cached object, so that callers changing the maps and slices
+// of what they read can't change the cache.
+func lookupCached[T any](rc *ReadCache, objects map[string]T, id string) *T {
+	rc.mu.Lock()
+	o, ok := objects[id]
+	rc.mu.Unlock()
+	if !ok {
+		return nil
+	}
+
+	data, err := json.Marshal(o)
+	if err != nil {
+		return nil
+	}
+	var c T
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil
+	}
+	return &c
+}
+
+func (c *ApiClient) cachedWorkspace(ctx context.Context, workspaceId string) *WorkspaceRead {
+	rc := c.ReadCache
+	if rc == nil {
+		return nil
+	}
+
+	rc.fill(ctx, "workspaces", func() error {
+		list, err := c.ListWorkspaces(ctx)
+		if err != nil {
+			return err
+		}
+		addCached(rc, rc.workspaces, list.Workspaces, func(w WorkspaceRead) string { return w.WorkspaceId })
+		return nil
+	})
+
+	return lookupCached(rc, rc.workspaces, workspaceId)
+}
+
+func (c *ApiClient) cachedSourceDefinition(ctx context.Context, sourceDefinitionId string) *SourceDefinitionRead {
+	rc := c.ReadCache
+	if rc == nil {
+		return nil
+	}
+
+	rc.fill(ctx, "source definitions", func() error {
+		list, err := c.ListSourceDefinitions(ctx)
+		if err != nil {
+			return err
+		}
+		addCached(rc, rc.sourceDefinitions, list.SourceDefinitions, func(sd SourceDefinitionRead) string { return sd.SourceDefinitionId })
+		return nil
+	})
+
+	return lookupCached(rc, rc.sourceDefinitions, sourceDefinitionId)
+}
+
+func (c *ApiClient) cachedDestinationDefinition(ctx context.Context, destinationDefinitionId string) *DestinationDefinitionRead {
+	rc := c.ReadCache
+	if rc == nil {
+		return nil
+	}
+
+	rc.fill(ctx, "destination definitions", func() error {
+		list, err := c.ListDestinationDefinitions(ctx)
+		if err != nil {
+			return err
+		}
+		addCached(rc, rc.destinationDefinitions, list.DestinationDefinitions, func(dd DestinationDefinitionRead) string { return dd.DestinationDefinitionId })
+		return nil
+	})
+
+	return lookupCached(rc, rc.destinationDefinitions, destinationDefinitionId)
+}
+
+// cachedSource can't know which workspace to list before the first read of a source, so sources
+// are cached a workspace at a time, after a read from that workspace misses.
+func (c *ApiClient) cachedSource(sourceId string) *SourceRead {
+	if c.ReadCache == nil {
+		return nil
+	}
+	return lookupCached(c.ReadCache, c.ReadCache.sources, sourceId)
+}
+
+func (c *ApiClient) prefetchSources(ctx context.Context, workspaceId string) {
+	rc := c.ReadCache
+	if rc == nil {
+		return
+	}
+
+	rc.fill(ctx, "sources of workspace "+workspaceId, func() error {
+		list, err := c.ListSourcesForWorkspace(ctx, WorkspaceIdRequestBody{WorkspaceId: workspaceId})
+		if err != nil {
+			return err
+		}
+		addCached(rc, rc.sources, list.Sources, func(s SourceRead) string { return s.SourceId })
+		return nil
+	})
+}
+
+// cachedDestination and prefetchDestinations cache destinations a workspace at a time, like sources.
+func (c *ApiClient) cachedDestination(destinationId string) *DestinationRead {
+	if c.ReadCache == nil {
+		return nil
+	}
+	return lookupCached(c.ReadCache, c.ReadCache.destinations, destinationId)
+}
+
+func (c *ApiClient) prefetchDestinations(ctx context.Context, workspaceId string) {
+	rc := c.ReadCache
+	if rc == nil {
+		return
+	}
+
+	rc.fill(ctx, "destinations of workspace "+workspaceId, func() error {
+		list, err := c.ListDestinationsForWorkspace(ctx, WorkspaceIdRequestBody{WorkspaceId: workspaceId})
+		if err != nil {
+			return err
+		}
+		addCached(rc, rc.destinations, list.Destinations, func(d DestinationRead) string { return d.DestinationId })
+		return nil
+	})
+}
+
+// writtenIdFields name the id of the object written by the endpoints of each cached kind, e.g.
+// sources/update and sources/delete write the source with the sourceId of their body. Creates have
+// no id yet, and the other ids of a body, like the workspaceId of a new source, are only references.
+var writtenIdFields = map[string]string{
+	"workspaces":              "workspaceId",
+	"source_definitions":      "sourceDefinitionId",
+	"destination_definitions": "destinationDefinitionId",
+	"sources":                 "sourceId",
+	"destinations":            "destinationId",
+}
+
+// invalidateCached drops the cached object a write to endpoint changed or deleted, so its next read
+// goes to the server. Fills still in flight won't add it back either.
+func (c *ApiClient) invalidateCached(endpoint string, reqBody []byte) {
+	if c.ReadCache == nil {
+		return
+	}
+
+	kind, _, _ := strings.Cut(endpoint, "/")
+	idField, ok := writtenIdFields[kind]
+	if !ok {
+		return
+	}
+
+	var fields map[string]any
+	if err := json.Unmarshal(reqBody, &fields); err != nil {
+		return
+	}
+	id, ok := fields[idField].(string)
+	if !ok || id == "" {
+		return
+	}
+
+	c.ReadCache.mu.Lock()
+	defer c.ReadCache.mu.Unlock()
+
+	c.ReadCache.written[id] = true
+	delete(c.ReadCache.workspaces, id)
+	delete(c.ReadCache.sourceDefinitions, id)
+	delete(c.ReadCache.destinationDefinitions, id)
+	delete(c.ReadCache.sources, id)
+	delete(c.ReadCache.destinations, id)
+}
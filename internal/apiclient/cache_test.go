@@ -0,0 +1,249 @@
+package apiclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func newCountingServer(t *testing.T) (*httptest.Server, map[string]int, *sync.Mutex) {
+	calls := make(map[string]int)
+	var mu sync.Mutex
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		calls[r.URL.Path]++
+		mu.Unlock()
+
+		body := map[string]string{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		switch r.URL.Path {
+		case "/api/v1/sources/get":
+			fmt.Fprintf(w, `{"sourceId":%q,"workspaceId":"ws"}`, body["sourceId"])
+		case "/api/v1/sources/list":
+			fmt.Fprint(w, `{"sources":[{"sourceId":"s1","workspaceId":"ws"},{"sourceId":"s2","workspaceId":"ws"},{"sourceId":"s3","workspaceId":"ws"}]}`)
+		case "/api/v1/sources/create":
+			fmt.Fprintf(w, `{"sourceId":"s4","workspaceId":%q}`, body["workspaceId"])
+		case "/api/v1/sources/update":
+			fmt.Fprintf(w, `{"sourceId":%q,"workspaceId":"ws","name":"updated"}`, body["sourceId"])
+		case "/api/v1/sources/discover_schema":
+			fmt.Fprint(w, `{"catalog":{"streams":[]},"catalogId":"c1","jobInfo":{"id":"j1","configType":"discover_schema","createdAt":1,"endedAt":2,"succeeded":true}}`)
+		case "/api/v1/workspaces/list":
+			fmt.Fprint(w, `{"workspaces":[{"workspaceId":"ws","name":"one"}]}`)
+		case "/api/v1/workspaces/get":
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"message":"not found"}`)
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	}))
+
+	return srv, calls, &mu
+}
+
+func TestReadCache_sources(t *testing.T) {
+	srv, calls, _ := newCountingServer(t)
+	defer srv.Close()
+
+	c := &ApiClient{HostURL: srv.URL, HTTPClient: srv.Client(), ReadCache: NewReadCache()}
+	ctx := context.Background()
+
+	for _, id := range []string{"s1", "s2", "s3", "s2"} {
+		s, err := c.GetSourceById(ctx, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s.SourceId != id {
+			t.Fatalf("expected %s, got %s", id, s.SourceId)
+		}
+	}
+	if calls["/api/v1/sources/get"] != 1 || calls["/api/v1/sources/list"] != 1 {
+		t.Fatalf("expected 1 get and 1 list, got %v", calls)
+	}
+
+	if _, err := c.UpdateSource(ctx, SourceUpdate{SourceId: "s2"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetSourceById(ctx, "s2"); err != nil {
+		t.Fatal(err)
+	}
+	if calls["/api/v1/sources/get"] != 2 {
+		t.Fatalf("expected the update to invalidate s2, got %v", calls)
+	}
+}
+
+func TestReadCache_createKeepsReferencedObjects(t *testing.T) {
+	srv, calls, _ := newCountingServer(t)
+	defer srv.Close()
+
+	c := &ApiClient{HostURL: srv.URL, HTTPClient: srv.Client(), ReadCache: NewReadCache()}
+	ctx := context.Background()
+
+	if _, err := c.GetWorkspaceById(ctx, "ws"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.CreateSource(ctx, SourceCreate{WorkspaceId: "ws", SourceDefinitionId: "sd", Name: "new"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetWorkspaceById(ctx, "ws"); err != nil {
+		t.Fatal(err)
+	}
+	if calls["/api/v1/workspaces/get"] != 0 || calls["/api/v1/workspaces/list"] != 1 {
+		t.Fatalf("expected the workspace of the new source to stay cached, got %v", calls)
+	}
+}
+
+func TestReadCache_discoverIsNotAWrite(t *testing.T) {
+	srv, calls, _ := newCountingServer(t)
+	defer srv.Close()
+
+	c := &ApiClient{HostURL: srv.URL, HTTPClient: srv.Client(), ReadCache: NewReadCache()}
+	ctx := context.Background()
+
+	if _, err := c.GetSourceById(ctx, "s1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.DiscoverSourceSchema(ctx, "s2", false); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetSourceById(ctx, "s2"); err != nil {
+		t.Fatal(err)
+	}
+	if calls["/api/v1/sources/get"] != 1 {
+		t.Fatalf("expected s2 to be read from the cache after discovering its schema, got %v", calls)
+	}
+}
+
+func TestReadCache_workspaceMissFallsBack(t *testing.T) {
+	srv, calls, _ := newCountingServer(t)
+	defer srv.Close()
+
+	c := &ApiClient{HostURL: srv.URL, HTTPClient: srv.Client(), ReadCache: NewReadCache()}
+	ctx := context.Background()
+
+	if _, err := c.GetWorkspaceById(ctx, "ws"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetWorkspaceById(ctx, "gone"); !IsNotFound(err) {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+	if calls["/api/v1/workspaces/list"] != 1 || calls["/api/v1/workspaces/get"] != 1 {
+		t.Fatalf("unexpected calls %v", calls)
+	}
+}
+
+func TestReadCache_concurrentFills(t *testing.T) {
+	var mu sync.Mutex
+	calls := make(map[string]int)
+	unblock := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		calls[r.URL.Path]++
+		mu.Unlock()
+
+		switch r.URL.Path {
+		case "/api/v1/workspaces/list":
+			<-unblock
+			fmt.Fprint(w, `{"workspaces":[{"workspaceId":"ws","name":"one"}]}`)
+		case "/api/v1/source_definitions/list":
+			fmt.Fprint(w, `{"sourceDefinitions":[{"sourceDefinitionId":"sd","name":"faker"}]}`)
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	c := &ApiClient{HostURL: srv.URL, HTTPClient: srv.Client(), ReadCache: NewReadCache()}
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.GetWorkspaceById(ctx, "ws"); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+
+	// The workspace list in flight doesn't hold up other lists.
+	if _, err := c.GetSourceDefinitionById(ctx, "sd"); err != nil {
+		t.Fatal(err)
+	}
+	close(unblock)
+	wg.Wait()
+
+	if calls["/api/v1/workspaces/list"] != 1 {
+		t.Fatalf("expected the concurrent reads to share a single list, got %v", calls)
+	}
+}
+
+func TestReadCache_failedFillIsRemembered(t *testing.T) {
+	var mu sync.Mutex
+	calls := make(map[string]int)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		calls[r.URL.Path]++
+		mu.Unlock()
+
+		switch r.URL.Path {
+		case "/api/v1/workspaces/list":
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"message":"forbidden"}`)
+		case "/api/v1/workspaces/get":
+			fmt.Fprint(w, `{"workspaceId":"ws","name":"one"}`)
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	c := &ApiClient{HostURL: srv.URL, HTTPClient: srv.Client(), ReadCache: NewReadCache()}
+	for i := 0; i < 3; i++ {
+		if _, err := c.GetWorkspaceById(context.Background(), "ws"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if calls["/api/v1/workspaces/list"] != 1 || calls["/api/v1/workspaces/get"] != 3 {
+		t.Fatalf("expected 1 list and 3 gets, got %v", calls)
+	}
+}
+
+func TestReadCache_returnsCopies(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/sources/get":
+			fmt.Fprint(w, `{"sourceId":"s1","workspaceId":"ws"}`)
+		case "/api/v1/sources/list":
+			fmt.Fprint(w, `{"sources":[{"sourceId":"s2","workspaceId":"ws","connectionConfiguration":{"nested":{"count":10}}}]}`)
+		}
+	}))
+	defer srv.Close()
+
+	c := &ApiClient{HostURL: srv.URL, HTTPClient: srv.Client(), ReadCache: NewReadCache()}
+	ctx := context.Background()
+
+	if _, err := c.GetSourceById(ctx, "s1"); err != nil {
+		t.Fatal(err)
+	}
+	s, err := c.GetSourceById(ctx, "s2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.ConnectionConfiguration["nested"].(map[string]any)["count"] = 0
+
+	again, err := c.GetSourceById(ctx, "s2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count := again.ConnectionConfiguration["nested"].(map[string]any)["count"]; count != float64(10) {
+		t.Fatalf("expected the cached source to be unchanged, got count %v", count)
+	}
+}
@@ -1,107 +1,216 @@
 package apiclient
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
+//go:generate go run ./gen -spec openapi/config.json -out api_gen.go
+
 const BASE_URL = "api/v1"
 
+// DefaultRequestTimeout is how long a single attempt of a request may take by default.
+const DefaultRequestTimeout = 2 * time.Minute
+
 type ApiClient struct {
-	HostURL    string `http://localhost:8000`
+	HostURL string
+	// BasePath is the path of the API below HostURL. Defaults to BASE_URL.
+	BasePath   string
+	Headers    map[string]string
 	HTTPClient *http.Client
+	Username   string
+	Password   string
+	// TokenSource, when set, takes precedence over basic auth and adds a bearer token to every request.
+	TokenSource TokenSource
+	// MaxRetries is how many times a request failing with a transient error is retried. Only
+	// read-style requests are retried unless RetryMutatingRequests is set.
+	MaxRetries            int
+	RetryMaxWait          time.Duration
+	RetryMutatingRequests bool
+	// RequestTimeout bounds each attempt of a request, like the Timeout of an http.Client. Heavy
+	// requests are only bounded by their context, since a discover job can run for many minutes.
+	RequestTimeout time.Duration
+	// Limiter applies to every request, HeavyLimiter additionally to the ones that start connector
+	// containers on the server (definition creation, check_connection, discover_schema).
+	Limiter      *Limiter
+	HeavyLimiter *Limiter
+	// ReadCache, when set, answers Get*ById calls from the list endpoints.
+	ReadCache *ReadCache
+	// ServerVersion is set by DetectServerVersion, and is nil when the version is unknown.
+	ServerVersion    *version.Version
+	RawServerVersion string
+
+	secretFields secretFieldSet
 }
 
-type HealthCheckResponse struct {
-	available bool
-}
+func (c *ApiClient) url(endpoint string) string {
+	basePath := c.BasePath
+	if basePath == "" {
+		basePath = BASE_URL
+	}
+	basePath = strings.Trim(basePath, "/")
 
-type CommonErrorResponseFields struct {
-	Message            string `json:"message"`
-	exceptionClassName string
-	exceptionStack     []string
+	if basePath == "" {
+		return fmt.Sprintf("%s/%s", strings.TrimRight(c.HostURL, "/"), endpoint)
+	}
+	return fmt.Sprintf("%s/%s/%s", strings.TrimRight(c.HostURL, "/"), basePath, endpoint)
 }
 
-type Response422 struct {
-	CommonErrorResponseFields
-	ValidationErrors []ValidationError `json:"validationErrors"`
-}
+// call sends a JSON request to an API endpoint and decodes the response into out, unless it is nil.
+// Every generated method goes through it. A nil in sends an empty object, as Airbyte expects for
+// endpoints without parameters.
+func (c *ApiClient) call(ctx context.Context, method string, endpoint string, in any, out any) error {
+	resBody, err := c.callRaw(ctx, method, endpoint, in)
+	if err != nil {
+		return err
+	}
 
-type ValidationError struct {
-	PropertyPath string `json:"propertyPath"`
-	InvalidValue string `json:"invalidValue"`
-	Message      string `json:"message"`
-}
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(resBody, out); err != nil {
+		return err
+	}
+	c.observeResponse(out)
 
-type Response404 struct {
-	Id string `json:"id"`
-	CommonErrorResponseFields
-	rootCauseExceptionClassName string
-	rootCauseExceptionStack     []string
+	return nil
 }
 
-type Response500 struct {
-	CommonErrorResponseFields
-}
+// callRaw is call for responses that are needed as the server sent them, returning the body.
+func (c *ApiClient) callRaw(ctx context.Context, method string, endpoint string, in any) ([]byte, error) {
+	ctx = WithLogSubsystem(ctx)
 
-func (c *ApiClient) check() error {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/%s/health", c.HostURL, BASE_URL), nil)
-	if err != nil {
-		return err
+	var body io.Reader
+	var rb []byte
+	if method != http.MethodGet {
+		if in == nil {
+			in = struct{}{}
+		}
+
+		var err error
+		rb, err = json.Marshal(in)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewReader(rb)
 	}
 
-	body, err := c.doRequest(req)
+	req, err := http.NewRequestWithContext(ctx, method, c.url(endpoint), body)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	hcr := HealthCheckResponse{}
-	err = json.Unmarshal(body, &hcr)
+	resBody, err := c.doRequest(req)
+	if !isReadRequest(req) {
+		c.invalidateCached(endpoint, rb)
+	}
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	return resBody, nil
 }
 
 func (c *ApiClient) doRequest(req *http.Request) ([]byte, error) {
+	maxRetries := 0
+	if (c.RetryMutatingRequests || isReadRequest(req)) && !retriesDisabled(req.Context()) {
+		maxRetries = c.MaxRetries
+	}
+
+	for attempt := 0; ; attempt++ {
+		body, retryAfter, err := c.doRequestOnce(req)
+		if err == nil || attempt >= maxRetries || !isRetryable(err) {
+			return body, err
+		}
+
+		wait := c.backoff(attempt, retryAfter)
+		tflog.SubsystemWarn(req.Context(), LogSubsystem, "Retrying Airbyte API request", map[string]any{
+			"method":  req.Method,
+			"path":    req.URL.Path,
+			"attempt": attempt + 1,
+			"wait":    wait.String(),
+			"error":   err.Error(),
+		})
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(wait):
+		}
+
+		if req.GetBody != nil {
+			req.Body, err = req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+func (c *ApiClient) doRequestOnce(req *http.Request) ([]byte, time.Duration, error) {
+	release, err := c.acquire(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer release()
+
+	if c.RequestTimeout > 0 && !isHeavyRequest(req) {
+		ctx, cancel := context.WithTimeout(req.Context(), c.RequestTimeout)
+		defer cancel()
+		req = req.WithContext(ctx)
+	}
+
 	req.Header.Set("Content-Type", "application/json")
+	for k, v := range c.Headers {
+		req.Header.Set(k, v)
+	}
+	if c.TokenSource != nil {
+		token, err := c.TokenSource.Token(req.Context())
+		if err != nil {
+			return nil, 0, err
+		}
+		req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+	} else if c.Username != "" || c.Password != "" {
+		req.SetBasicAuth(c.Username, c.Password)
+	}
+
+	var reqBody []byte
+	if req.GetBody != nil {
+		if rc, err := req.GetBody(); err == nil {
+			reqBody, _ = io.ReadAll(rc)
+			rc.Close()
+		}
+	}
+	c.logRequest(req.Context(), req, reqBody)
+	start := time.Now()
 
 	res, err := c.HTTPClient.Do(req)
 	if err != nil {
-		return nil, err
+		return nil, 0, &transportError{err: err}
 	}
 	defer res.Body.Close()
 
 	body, err := io.ReadAll(res.Body)
 	if err != nil {
-		return nil, err
+		return nil, 0, &transportError{err: err}
 	}
 
+	c.logResponse(req.Context(), req, res, body, start)
+
 	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
-		if res.StatusCode == http.StatusUnprocessableEntity {
-			r := Response422{}
-			err := json.Unmarshal(body, &r)
-			if err == nil {
-				body, _ = json.Marshal(r)
-			}
-		} else if res.StatusCode == http.StatusNotFound {
-			r := Response404{}
-			err := json.Unmarshal(body, &r)
-			if err == nil {
-				body, _ = json.Marshal(r)
-			}
-		} else if res.StatusCode == http.StatusInternalServerError {
-			r := Response500{}
-			err := json.Unmarshal(body, &r)
-			if err == nil {
-				body, _ = json.Marshal(r)
-			}
-		}
-		return nil, fmt.Errorf("status: %d, body: %s", res.StatusCode, body)
+		apiErr := newAPIError(res.StatusCode, body)
+		c.redactAPIError(apiErr)
+		return nil, retryAfter(res), apiErr
 	}
 
-	return body, err
+	return body, 0, nil
 }
@@ -0,0 +1,336 @@
+package apiclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// The contract test checks the request and response types of this package against an OpenAPI
+// document of the Airbyte config API. By default that is the vendored subset the types are generated
+// from, which only checks the hand-written types and the generator; `make contract`, which CI runs,
+// points AIRBYTE_OPENAPI_SPEC at the upstream document of AIRBYTE_VERSION instead, and any other
+// release's spec shows what would drift when moving to it.
+const defaultOpenAPISpec = "openapi/config.json"
+
+type openAPIDocument struct {
+	Components struct {
+		Schemas map[string]*openAPISchema `json:"schemas"`
+	} `json:"components"`
+}
+
+type openAPISchema struct {
+	Ref        string                    `json:"$ref"`
+	Type       string                    `json:"type"`
+	Format     string                    `json:"format"`
+	Required   []string                  `json:"required"`
+	Properties map[string]*openAPISchema `json:"properties"`
+	Items      *openAPISchema            `json:"items"`
+}
+
+// contracts maps the API types the provider uses to the schema they are sent or received as.
+// Generated types match the vendored spec by construction; checking them against a newer spec shows
+// what regenerating would change. Request types must send every property the schema requires.
+var contracts = []struct {
+	value   any
+	schema  string
+	request bool
+}{
+	{WorkspaceIdRequestBody{}, "WorkspaceIdRequestBody", true},
+	{WorkspaceRead{}, "WorkspaceRead", false},
+	{WorkspaceCreate{}, "WorkspaceCreate", true},
+	{WorkspaceUpdate{}, "WorkspaceUpdate", true},
+	{Notification{}, "Notification", true},
+	{SlackNotificationConfiguration{}, "SlackNotificationConfiguration", true},
+	{SourceDefinitionIdRequestBody{}, "SourceDefinitionIdRequestBody", true},
+	{SourceDefinitionRead{}, "SourceDefinitionRead", false},
+	{SourceDefinitionCreate{}, "SourceDefinitionCreate", true},
+	{CustomSourceDefinitionCreate{}, "CustomSourceDefinitionCreate", true},
+	{SourceDefinitionUpdate{}, "SourceDefinitionUpdate", true},
+	{ActorDefinitionResourceRequirements{}, "ActorDefinitionResourceRequirements", true},
+	{ResourceRequirements{}, "ResourceRequirements", true},
+	{JobTypeResourceLimit{}, "JobTypeResourceLimit", true},
+	{SourceDefinitionSpecificationRead{}, "SourceDefinitionSpecificationRead", false},
+	{DestinationDefinitionIdRequestBody{}, "DestinationDefinitionIdRequestBody", true},
+	{DestinationDefinitionRead{}, "DestinationDefinitionRead", false},
+	{DestinationDefinitionCreate{}, "DestinationDefinitionCreate", true},
+	{CustomDestinationDefinitionCreate{}, "CustomDestinationDefinitionCreate", true},
+	{DestinationDefinitionUpdate{}, "DestinationDefinitionUpdate", true},
+	{NormalizationDestinationDefinitionConfig{}, "NormalizationDestinationDefinitionConfig", false},
+	{DestinationDefinitionIdWithWorkspaceId{}, "DestinationDefinitionIdWithWorkspaceId", true},
+	{DestinationDefinitionSpecificationRead{}, "DestinationDefinitionSpecificationRead", false},
+	{DestinationIdRequestBody{}, "DestinationIdRequestBody", true},
+	{DestinationRead{}, "DestinationRead", false},
+	{DestinationCreate{}, "DestinationCreate", true},
+	{DestinationUpdate{}, "DestinationUpdate", true},
+	{SourceIdRequestBody{}, "SourceIdRequestBody", true},
+	{SourceRead{}, "SourceRead", false},
+	{SourceCreate{}, "SourceCreate", true},
+	{SourceUpdate{}, "SourceUpdate", true},
+	{SourceDiscoverSchemaRequestBody{}, "SourceDiscoverSchemaRequestBody", true},
+	{SourceDiscoverSchemaRead{}, "SourceDiscoverSchemaRead", false},
+	{SynchronousJobRead{}, "SynchronousJobRead", false},
+	{FailureReason{}, "FailureReason", false},
+	{ConnectionIdRequestBody{}, "ConnectionIdRequestBody", true},
+	{ConnectionRead{}, "ConnectionRead", false},
+	{ConnectionCreate{}, "ConnectionCreate", true},
+	{ConnectionUpdate{}, "ConnectionUpdate", true},
+	{ConnectionScheduleData{}, "ConnectionScheduleData", true},
+	{AirbyteCatalog{}, "AirbyteCatalog", true},
+	{AirbyteStreamAndConfiguration{}, "AirbyteStreamAndConfiguration", true},
+	{AirbyteStream{}, "AirbyteStream", true},
+	{AirbyteStreamConfiguration{}, "AirbyteStreamConfiguration", true},
+	{SelectedFieldInfo{}, "SelectedFieldInfo", true},
+	{DeploymentMetadataRead{}, "DeploymentMetadataRead", false},
+	{HealthCheckRead{}, "HealthCheckRead", false},
+	{ValidationError{}, "InvalidInputProperty", false},
+}
+
+// notAPITypes are hand-written exported types with JSON tags that aren't Airbyte config API schemas.
+var notAPITypes = map[string]string{
+	"APIError":         "decodes the union of the Airbyte error bodies",
+	"Cassette":         "recorder file format",
+	"Interaction":      "recorder file format",
+	"RecordedRequest":  "recorder file format",
+	"RecordedResponse": "recorder file format",
+}
+
+func loadOpenAPIDocument(t *testing.T) *openAPIDocument {
+	path := os.Getenv("AIRBYTE_OPENAPI_SPEC")
+	if path == "" {
+		path = defaultOpenAPISpec
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	doc := &openAPIDocument{}
+	if err := json.Unmarshal(data, doc); err != nil {
+		t.Fatalf("unable to parse %s: %s", path, err)
+	}
+
+	return doc
+}
+
+func TestContract(t *testing.T) {
+	doc := loadOpenAPIDocument(t)
+
+	schemaOf := make(map[reflect.Type]string, len(contracts))
+	for _, c := range contracts {
+		schemaOf[reflect.TypeOf(c.value)] = c.schema
+	}
+
+	for _, c := range contracts {
+		typ := reflect.TypeOf(c.value)
+		t.Run(typ.Name(), func(t *testing.T) {
+			schema, ok := doc.Components.Schemas[c.schema]
+			if !ok {
+				t.Fatalf("schema %s does not exist in the spec", c.schema)
+			}
+
+			for _, problem := range checkContract(doc, schemaOf, typ, schema, c.request) {
+				t.Errorf("%s (%s): %s", typ.Name(), c.schema, problem)
+			}
+		})
+	}
+}
+
+// TestContract_coversAllTypes fails when a hand-written API type is added without a contract.
+func TestContract_coversAllTypes(t *testing.T) {
+	covered := make(map[string]bool, len(contracts))
+	for _, c := range contracts {
+		covered[reflect.TypeOf(c.value).Name()] = true
+	}
+
+	pkgs, err := parser.ParseDir(token.NewFileSet(), ".", func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go") && fi.Name() != "api_gen.go"
+	}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			ast.Inspect(file, func(n ast.Node) bool {
+				spec, ok := n.(*ast.TypeSpec)
+				if !ok || !spec.Name.IsExported() || spec.Assign.IsValid() {
+					return true
+				}
+				st, ok := spec.Type.(*ast.StructType)
+				if !ok || !hasJSONTags(st) {
+					return true
+				}
+				if _, skip := notAPITypes[spec.Name.Name]; !skip && !covered[spec.Name.Name] {
+					t.Errorf("%s has no contract; add it to contracts or notAPITypes", spec.Name.Name)
+				}
+				return true
+			})
+		}
+	}
+}
+
+func hasJSONTags(st *ast.StructType) bool {
+	for _, f := range st.Fields.List {
+		if f.Tag != nil && strings.Contains(f.Tag.Value, `json:"`) {
+			return true
+		}
+	}
+	return false
+}
+
+type jsonField struct {
+	name      string
+	goName    string
+	typ       reflect.Type
+	omitempty bool
+}
+
+// jsonFields lists the fields encoding/json sees on t, including the ones promoted from embedded structs.
+func jsonFields(t reflect.Type) []jsonField {
+	var fields []jsonField
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			fields = append(fields, jsonFields(f.Type)...)
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		fields = append(fields, jsonField{
+			name:      name,
+			goName:    t.Name() + "." + f.Name,
+			typ:       f.Type,
+			omitempty: strings.Contains(opts, "omitempty"),
+		})
+	}
+
+	return fields
+}
+
+func checkContract(doc *openAPIDocument, schemaOf map[reflect.Type]string, typ reflect.Type, schema *openAPISchema, request bool) []string {
+	var problems []string
+
+	byName := make(map[string][]jsonField)
+	for _, f := range jsonFields(typ) {
+		byName[f.name] = append(byName[f.name], f)
+	}
+
+	names := make([]string, 0, len(byName))
+	for name := range byName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		fields := byName[name]
+		if len(fields) > 1 {
+			goNames := make([]string, len(fields))
+			for i, f := range fields {
+				goNames[i] = f.goName
+			}
+			problems = append(problems, fmt.Sprintf("%s is declared more than once (%s)", name, strings.Join(goNames, ", ")))
+		}
+
+		prop, ok := schema.Properties[name]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("%s is not a property of the schema", name))
+			continue
+		}
+		for _, f := range fields {
+			if problem := checkType(doc, schemaOf, f.typ, prop); problem != "" {
+				problems = append(problems, fmt.Sprintf("%s: %s", name, problem))
+			}
+		}
+	}
+
+	if request {
+		for _, name := range schema.Required {
+			fields, ok := byName[name]
+			switch {
+			case !ok:
+				problems = append(problems, fmt.Sprintf("required property %s is missing", name))
+			case fields[0].omitempty:
+				problems = append(problems, fmt.Sprintf("required property %s is omitempty (%s)", name, fields[0].goName))
+			}
+		}
+	}
+
+	return problems
+}
+
+// checkType returns why a field of type typ can't hold values of schema, or "" if it can.
+func checkType(doc *openAPIDocument, schemaOf map[reflect.Type]string, typ reflect.Type, schema *openAPISchema) string {
+	refName := ""
+	for schema.Ref != "" {
+		refName = strings.TrimPrefix(schema.Ref, "#/components/schemas/")
+		resolved, ok := doc.Components.Schemas[refName]
+		if !ok {
+			return fmt.Sprintf("unresolvable reference %s", schema.Ref)
+		}
+		schema = resolved
+	}
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	if schema.Type == "" && schema.Properties == nil {
+		// Free-form values, like connector configurations.
+		return ""
+	}
+
+	expected := ""
+	switch typ.Kind() {
+	case reflect.String:
+		expected = "string"
+	case reflect.Bool:
+		expected = "boolean"
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		expected = "integer"
+	case reflect.Float32, reflect.Float64:
+		expected = "number"
+	case reflect.Slice:
+		expected = "array"
+	case reflect.Map, reflect.Struct:
+		expected = "object"
+	case reflect.Interface:
+		return ""
+	}
+	if schema.Type != expected && !(expected == "object" && schema.Type == "" && schema.Properties != nil) {
+		return fmt.Sprintf("%s can't hold a %s", typ, schema.Type)
+	}
+
+	switch typ.Kind() {
+	case reflect.Slice:
+		if schema.Items == nil {
+			return ""
+		}
+		return checkType(doc, schemaOf, typ.Elem(), schema.Items)
+	case reflect.Struct:
+		if own, ok := schemaOf[typ]; ok && refName != "" && own != refName {
+			return fmt.Sprintf("%s is checked against %s, but the spec uses %s", typ.Name(), own, refName)
+		}
+	}
+
+	return ""
+}
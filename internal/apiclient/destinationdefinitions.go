@@ -0,0 +1,14 @@
+package apiclient
+
+import (
+	"context"
+)
+
+// GetDestinationDefinitionById reads a destination definition, from the ReadCache when there is one.
+func (c *ApiClient) GetDestinationDefinitionById(ctx context.Context, destinationDefinitionId string) (*DestinationDefinitionRead, error) {
+	if dd := c.cachedDestinationDefinition(ctx, destinationDefinitionId); dd != nil {
+		return dd, nil
+	}
+
+	return c.GetDestinationDefinition(ctx, DestinationDefinitionIdRequestBody{DestinationDefinitionId: destinationDefinitionId})
+}
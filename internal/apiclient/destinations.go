@@ -0,0 +1,22 @@
+package apiclient
+
+import (
+	"context"
+)
+
+// GetDestinationById reads a destination, from the ReadCache when there is one. A read that misses
+// the cache fills it with the other destinations of the same workspace.
+func (c *ApiClient) GetDestinationById(ctx context.Context, destinationId string) (*DestinationRead, error) {
+	if d := c.cachedDestination(destinationId); d != nil {
+		return d, nil
+	}
+
+	d, err := c.GetDestination(ctx, DestinationIdRequestBody{DestinationId: destinationId})
+	if err != nil {
+		return nil, err
+	}
+
+	c.prefetchDestinations(ctx, d.WorkspaceId)
+
+	return d, nil
+}
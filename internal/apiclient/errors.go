@@ -0,0 +1,103 @@
+package apiclient
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// APIError is returned for every non-2xx response from the Airbyte API. It decodes the
+// KnownExceptionInfo/NotFoundKnownExceptionInfo/InvalidInputExceptionInfo bodies Airbyte sends, and
+// can be matched with errors.As.
+type APIError struct {
+	StatusCode                  int               `json:"-"`
+	Message                     string            `json:"message"`
+	ExceptionClassName          string            `json:"exceptionClassName,omitempty"`
+	ExceptionStack              []string          `json:"exceptionStack,omitempty"`
+	RootCauseExceptionClassName string            `json:"rootCauseExceptionClassName,omitempty"`
+	RootCauseExceptionStack     []string          `json:"rootCauseExceptionStack,omitempty"`
+	Id                          string            `json:"id,omitempty"`
+	ValidationErrors            []ValidationError `json:"validationErrors,omitempty"`
+	// Body is the raw response body, kept for responses that aren't Airbyte JSON errors (e.g. from a proxy).
+	Body string `json:"-"`
+}
+
+type ValidationError struct {
+	PropertyPath string `json:"propertyPath"`
+	InvalidValue string `json:"invalidValue"`
+	Message      string `json:"message"`
+}
+
+func newAPIError(statusCode int, body []byte) *APIError {
+	apiErr := &APIError{}
+	if err := json.Unmarshal(body, apiErr); err != nil {
+		apiErr = &APIError{}
+	}
+	apiErr.StatusCode = statusCode
+	apiErr.Body = string(body)
+
+	return apiErr
+}
+
+func (e *APIError) Error() string {
+	if e.IsAuthError() {
+		return fmt.Sprintf("authentication failed: Airbyte rejected the configured credentials (status: %d)", e.StatusCode)
+	}
+
+	msg := e.Message
+	if msg == "" {
+		msg = strings.TrimSpace(e.Body)
+	}
+	if msg == "" {
+		msg = http.StatusText(e.StatusCode)
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "status: %d, message: %s", e.StatusCode, msg)
+	for _, ve := range e.ValidationErrors {
+		fmt.Fprintf(&sb, "; %s: %s", ve.PropertyPath, ve.Message)
+	}
+
+	return sb.String()
+}
+
+func (e *APIError) IsAuthError() bool {
+	return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+}
+
+// StatusCode returns the HTTP status of the APIError in err's chain, or 0 if there is none.
+func StatusCode(err error) int {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
+
+// IsNotFound reports whether err means the requested object doesn't exist. Some Airbyte versions
+// answer with a 500 wrapping a ConfigNotFoundException instead of a 404.
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return apiErr.StatusCode == http.StatusNotFound ||
+		strings.HasSuffix(apiErr.ExceptionClassName, "ConfigNotFoundException") ||
+		strings.HasSuffix(apiErr.RootCauseExceptionClassName, "ConfigNotFoundException")
+}
+
+// IsUnavailable reports whether err means the server couldn't be reached, or the gateway in front of
+// it couldn't reach it, as happens while Airbyte is starting.
+func IsUnavailable(err error) bool {
+	var te *transportError
+	if errors.As(err, &te) {
+		return true
+	}
+	switch StatusCode(err) {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,453 @@
+// Command gen generates the request and response types and the client methods of the apiclient
+// package from the Airbyte OpenAPI document in openapi/config.json. Run it with
+// `go generate ./internal/apiclient`.
+//
+// The document is a hand-maintained subset of the upstream config API, holding the paths and schemas
+// the provider uses. Each operation listed in operations becomes an ApiClient method named after its
+// operationId that goes through ApiClient.call, so auth, retries, limits and logging apply to all of
+// them. The object and string enum schemas those operations use become types, along with the ones
+// listed in schemas.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+type document struct {
+	Paths      map[string]map[string]json.RawMessage `json:"paths"`
+	Components struct {
+		Schemas map[string]*schema `json:"schemas"`
+	} `json:"components"`
+}
+
+type operation struct {
+	OperationId string `json:"operationId"`
+	Summary     string `json:"summary"`
+	RequestBody *struct {
+		Content map[string]struct {
+			Schema *schema `json:"schema"`
+		} `json:"content"`
+	} `json:"requestBody"`
+	Responses map[string]struct {
+		Content map[string]struct {
+			Schema *schema `json:"schema"`
+		} `json:"content"`
+	} `json:"responses"`
+}
+
+type schema struct {
+	Ref         string             `json:"$ref"`
+	Type        string             `json:"type"`
+	Format      string             `json:"format"`
+	Description string             `json:"description"`
+	Nullable    bool               `json:"nullable"`
+	Enum        []any              `json:"enum"`
+	Required    []string           `json:"required"`
+	Properties  map[string]*schema `json:"properties"`
+	Items       *schema            `json:"items"`
+	AllOf       []*schema          `json:"allOf"`
+}
+
+func (s *schema) isObject() bool {
+	return len(s.Properties) > 0
+}
+
+func (s *schema) isEnum() bool {
+	return s.Type == "string" && len(s.Enum) > 0
+}
+
+// operations are the operationIds of the API the client calls. To call another endpoint, copy its
+// path and schemas from the upstream document into config.json, add its operationId here and run go
+// generate.
+var operations = []string{
+	"getHealthCheck",
+	"getDeploymentMetadata",
+
+	"createWorkspace",
+	"deleteWorkspace",
+	"getWorkspace",
+	"getWorkspaceBySlug",
+	"listWorkspaces",
+	"updateWorkspace",
+
+	"createSourceDefinition",
+	"createCustomSourceDefinition",
+	"deleteSourceDefinition",
+	"getSourceDefinition",
+	"listSourceDefinitions",
+	"updateSourceDefinition",
+	"getSourceDefinitionSpecification",
+
+	"createDestinationDefinition",
+	"createCustomDestinationDefinition",
+	"deleteDestinationDefinition",
+	"getDestinationDefinition",
+	"listDestinationDefinitions",
+	"updateDestinationDefinition",
+	"getDestinationDefinitionSpecification",
+
+	"createSource",
+	"deleteSource",
+	"discoverSchemaForSource",
+	"getSource",
+	"listSourcesForWorkspace",
+	"updateSource",
+
+	"createDestination",
+	"deleteDestination",
+	"getDestination",
+	"listDestinationsForWorkspace",
+	"updateDestination",
+
+	"createConnection",
+	"deleteConnection",
+	"getConnection",
+	"listConnectionsForWorkspace",
+	"updateConnection",
+}
+
+// schemas are generated even though no operation's request or success response uses them, like the
+// error bodies ApiError decodes.
+var schemas = []string{
+	"InvalidInputExceptionInfo",
+	"InvalidInputProperty",
+	"KnownExceptionInfo",
+	"NotFoundKnownExceptionInfo",
+}
+
+// patchFields are the optional properties generated as pointers, even though the document doesn't
+// mark them nullable, because the server leaves them alone when they are missing from an update:
+// only a pointer to "" can clear them.
+var patchFields = map[string]bool{
+	"ConnectionUpdate.namespaceFormat": true,
+	"ConnectionUpdate.prefix":          true,
+	"WorkspaceUpdate.email":            true,
+}
+
+type generator struct {
+	doc *document
+
+	// decls holds the generated type declarations by name; kinds records whether each is a struct or an enum.
+	decls map[string]string
+	kinds map[string]string
+}
+
+func main() {
+	specPath := flag.String("spec", "openapi/config.json", "OpenAPI document to generate from")
+	outPath := flag.String("out", "api_gen.go", "file to write")
+	pkg := flag.String("package", "apiclient", "package name of the generated file")
+	flag.Parse()
+
+	data, err := os.ReadFile(*specPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	doc := &document{}
+	if err := json.Unmarshal(data, doc); err != nil {
+		log.Fatalf("unable to parse %s: %s", *specPath, err)
+	}
+
+	src, err := generate(doc, *pkg)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.WriteFile(*outPath, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func generate(doc *document, pkg string) ([]byte, error) {
+	g := &generator{doc: doc, decls: make(map[string]string), kinds: make(map[string]string)}
+
+	for _, name := range sortedKeys(doc.Components.Schemas) {
+		doc.Components.Schemas[name] = g.flatten(doc.Components.Schemas[name])
+	}
+
+	methods, err := g.methods()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range schemas {
+		s := doc.Components.Schemas[name]
+		if s == nil || !(s.isObject() || s.isEnum()) {
+			return nil, fmt.Errorf("schema %s isn't an object or enum of the document", name)
+		}
+		g.namedType(name, s)
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by gen from openapi/config.json; DO NOT EDIT.\n\npackage %s\n\n", pkg)
+	buf.WriteString("import \"context\"\n\n")
+	for _, name := range sortedKeys(g.decls) {
+		buf.WriteString(g.decls[name])
+	}
+	buf.WriteString(methods)
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("generated code doesn't compile: %w\n%s", err, buf.Bytes())
+	}
+	return src, nil
+}
+
+// namedType generates the declaration of an object or enum schema once, returning its name.
+func (g *generator) namedType(name string, s *schema) string {
+	if _, ok := g.kinds[name]; ok {
+		return name
+	}
+
+	var buf bytes.Buffer
+	writeComment(&buf, s.Description)
+
+	if s.isEnum() {
+		g.kinds[name] = "enum"
+		fmt.Fprintf(&buf, "type %s string\n\nconst (\n", name)
+		for _, v := range s.Enum {
+			value := fmt.Sprint(v)
+			fmt.Fprintf(&buf, "\t%s%s %s = %q\n", name, goName(value), name, value)
+		}
+		buf.WriteString(")\n\n")
+		g.decls[name] = buf.String()
+		return name
+	}
+
+	g.kinds[name] = "struct"
+
+	required := make(map[string]bool, len(s.Required))
+	for _, r := range s.Required {
+		required[r] = true
+	}
+
+	fmt.Fprintf(&buf, "type %s struct {\n", name)
+	for _, prop := range sortedKeys(s.Properties) {
+		ps := s.Properties[prop]
+		nullable := ps.Nullable || patchFields[name+"."+prop]
+		typ, omitempty := g.fieldType(g.goType(ps, name+goName(prop)), required[prop], nullable)
+
+		writeComment(&buf, ps.Description)
+		tag := prop
+		if omitempty {
+			tag += ",omitempty"
+		}
+		fmt.Fprintf(&buf, "\t%s %s `json:%q`\n", goName(prop), typ, tag)
+	}
+	buf.WriteString("}\n\n")
+
+	g.decls[name] = buf.String()
+	return name
+}
+
+// flatten merges the parts of allOf schemas, which the document uses to extend objects, into one
+// object schema.
+func (g *generator) flatten(s *schema) *schema {
+	if s == nil {
+		return nil
+	}
+	for prop, ps := range s.Properties {
+		s.Properties[prop] = g.flatten(ps)
+	}
+	s.Items = g.flatten(s.Items)
+	if len(s.AllOf) == 0 {
+		return s
+	}
+
+	merged := &schema{Type: "object", Description: s.Description, Nullable: s.Nullable, Properties: map[string]*schema{}}
+	for _, part := range append(s.AllOf, &schema{Properties: s.Properties, Required: s.Required}) {
+		if part.Ref != "" {
+			target := g.doc.Components.Schemas[refName(part.Ref)]
+			if target == nil {
+				log.Fatalf("unresolvable reference %s", part.Ref)
+			}
+			part = g.flatten(target)
+		} else {
+			part = g.flatten(part)
+		}
+		for prop, ps := range part.Properties {
+			merged.Properties[prop] = ps
+		}
+		merged.Required = append(merged.Required, part.Required...)
+		if merged.Description == "" {
+			merged.Description = part.Description
+		}
+	}
+	return merged
+}
+
+// goType returns the Go type for values of s; hint names the type generated for an inline object.
+func (g *generator) goType(s *schema, hint string) string {
+	if s.Ref != "" {
+		name := refName(s.Ref)
+		target := g.doc.Components.Schemas[name]
+		if target == nil {
+			log.Fatalf("unresolvable reference %s", s.Ref)
+		}
+		if target.isObject() || target.isEnum() {
+			return g.namedType(name, target)
+		}
+		return g.goType(target, hint)
+	}
+
+	switch {
+	case s.isObject():
+		return g.namedType(hint, s)
+	case s.Type == "string":
+		return "string"
+	case s.Type == "boolean":
+		return "bool"
+	case s.Type == "integer" && s.Format == "int64":
+		return "int64"
+	case s.Type == "integer":
+		return "int"
+	case s.Type == "number":
+		return "float64"
+	case s.Type == "array" && s.Items != nil:
+		return "[]" + g.goType(s.Items, hint+"Item")
+	default:
+		// Free-form objects, like connector configurations and specifications.
+		return "map[string]any"
+	}
+}
+
+// fieldType decides how a property is declared. Optional scalars and objects are pointers so that
+// false, 0 and empty objects can be told apart from unset values; optional arrays aren't omitted,
+// so that an empty list can be sent to clear one. Optional strings are omitted when empty, unless
+// the property is nullable or one of the patchFields, to tell "clear" from "leave alone".
+func (g *generator) fieldType(typ string, required bool, nullable bool) (string, bool) {
+	switch {
+	case required:
+		return typ, false
+	case strings.HasPrefix(typ, "[]"):
+		return typ, false
+	case nullable && !strings.HasPrefix(typ, "map["):
+		return "*" + typ, true
+	case strings.HasPrefix(typ, "map["), typ == "string", g.kinds[typ] == "enum":
+		return typ, true
+	default:
+		return "*" + typ, true
+	}
+}
+
+func (g *generator) methods() (string, error) {
+	var buf bytes.Buffer
+
+	wanted := make(map[string]bool, len(operations))
+	for _, id := range operations {
+		wanted[id] = true
+	}
+
+	for _, path := range sortedKeys(g.doc.Paths) {
+		for _, method := range []string{"get", "post"} {
+			raw, ok := g.doc.Paths[path][method]
+			if !ok {
+				continue
+			}
+
+			op := &operation{}
+			if err := json.Unmarshal(raw, op); err != nil {
+				return "", fmt.Errorf("%s %s: %w", method, path, err)
+			}
+			if !wanted[op.OperationId] {
+				continue
+			}
+			delete(wanted, op.OperationId)
+
+			g.method(&buf, strings.ToUpper(method), path, op)
+		}
+	}
+
+	if len(wanted) > 0 {
+		return "", fmt.Errorf("operations missing from the document: %s", strings.Join(sortedKeys(wanted), ", "))
+	}
+
+	return buf.String(), nil
+}
+
+func (g *generator) method(buf *bytes.Buffer, method string, path string, op *operation) {
+	name := goName(op.OperationId)
+	endpoint := strings.TrimPrefix(strings.TrimPrefix(path, "/"), "v1/")
+
+	params := "ctx context.Context"
+	in := "nil"
+	if op.RequestBody != nil {
+		if content, ok := op.RequestBody.Content["application/json"]; ok {
+			params += ", body " + g.goType(content.Schema, name+"Request")
+			in = "body"
+		}
+	}
+
+	out := ""
+	if content, ok := op.Responses["200"].Content["application/json"]; ok {
+		out = g.goType(content.Schema, name+"Response")
+	}
+
+	summary := strings.TrimSuffix(strings.TrimSpace(op.Summary), ".")
+	fmt.Fprintf(buf, "// %s calls %s %s: %s.\n", name, method, path, summary)
+
+	switch {
+	case out == "":
+		fmt.Fprintf(buf, "func (c *ApiClient) %s(%s) error {\n", name, params)
+		fmt.Fprintf(buf, "\treturn c.call(ctx, %q, %q, %s, nil)\n}\n\n", method, endpoint, in)
+	case g.kinds[out] == "struct":
+		fmt.Fprintf(buf, "func (c *ApiClient) %s(%s) (*%s, error) {\n", name, params, out)
+		fmt.Fprintf(buf, "\tout := &%s{}\n", out)
+		fmt.Fprintf(buf, "\tif err := c.call(ctx, %q, %q, %s, out); err != nil {\n\t\treturn nil, err\n\t}\n", method, endpoint, in)
+		buf.WriteString("\treturn out, nil\n}\n\n")
+	default:
+		fmt.Fprintf(buf, "func (c *ApiClient) %s(%s) (%s, error) {\n", name, params, out)
+		fmt.Fprintf(buf, "\tvar out %s\n", out)
+		fmt.Fprintf(buf, "\terr := c.call(ctx, %q, %q, %s, &out)\n", method, endpoint, in)
+		buf.WriteString("\treturn out, err\n}\n\n")
+	}
+}
+
+func writeComment(buf *bytes.Buffer, description string) {
+	for _, line := range strings.Split(strings.TrimSpace(description), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			fmt.Fprintf(buf, "// %s\n", line)
+		}
+	}
+}
+
+func refName(ref string) string {
+	return strings.TrimPrefix(ref, "#/components/schemas/")
+}
+
+// goName turns a property, operation or enum value name into an exported Go identifier:
+// cpu_request becomes CpuRequest and createWorkspace becomes CreateWorkspace.
+func goName(name string) string {
+	var sb strings.Builder
+	upper := true
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,42 @@
+package apiclient
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const DefaultHealthPollInterval = 5 * time.Second
+
+// WaitForHealthy polls the health endpoint until Airbyte reports itself available or timeout
+// elapses. Connection errors are expected while the server starts, and are only logged. Each poll
+// is a single attempt, so that retry backoffs don't stretch the wait past timeout.
+func (c *ApiClient) WaitForHealthy(ctx context.Context, timeout time.Duration, interval time.Duration) error {
+	ctx, cancel := context.WithTimeout(withoutRetries(WithLogSubsystem(ctx)), timeout)
+	defer cancel()
+
+	var lastErr error
+	for {
+		hcr, err := c.GetHealthCheck(ctx)
+		if err == nil && hcr.Available {
+			return nil
+		}
+		if err != nil {
+			lastErr = err
+		} else {
+			lastErr = fmt.Errorf("server reported itself unavailable")
+		}
+
+		tflog.SubsystemDebug(ctx, LogSubsystem, "Waiting for Airbyte to become healthy", map[string]any{
+			"error": lastErr.Error(),
+		})
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("Airbyte at %s was not healthy after %s: %w", c.HostURL, timeout, lastErr)
+		case <-time.After(interval):
+		}
+	}
+}
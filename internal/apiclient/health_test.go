@@ -0,0 +1,73 @@
+package apiclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWaitForHealthy(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/health" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch atomic.AddInt32(&calls, 1) {
+		case 1:
+			w.WriteHeader(http.StatusBadGateway)
+		case 2:
+			fmt.Fprint(w, `{"available":false}`)
+		default:
+			fmt.Fprint(w, `{"available":true}`)
+		}
+	}))
+	defer srv.Close()
+
+	c := &ApiClient{HostURL: srv.URL, HTTPClient: srv.Client()}
+	if err := c.WaitForHealthy(context.Background(), 5*time.Second, time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 3 {
+		t.Fatalf("expected 3 health checks, got %d", calls)
+	}
+}
+
+func TestWaitForHealthy_timeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"available":false}`)
+	}))
+	defer srv.Close()
+
+	c := &ApiClient{HostURL: srv.URL, HTTPClient: srv.Client()}
+	if err := c.WaitForHealthy(context.Background(), 50*time.Millisecond, 10*time.Millisecond); err == nil {
+		t.Fatal("expected a timeout error")
+	}
+}
+
+func TestWaitForHealthy_singleAttemptPerPoll(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Retry-After", "10")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	c := &ApiClient{HostURL: srv.URL, HTTPClient: srv.Client(), MaxRetries: DefaultMaxRetries, RetryMaxWait: DefaultRetryMaxWait}
+
+	start := time.Now()
+	if err := c.WaitForHealthy(context.Background(), 200*time.Millisecond, 50*time.Millisecond); err == nil {
+		t.Fatal("expected a timeout error")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected the wait to end at its timeout, took %s", elapsed)
+	}
+	if calls < 2 {
+		t.Errorf("expected a health check per poll, got %d", calls)
+	}
+}
@@ -0,0 +1,121 @@
+package apiclient
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// heavyRequestSuffixes are endpoints that make the server start connector containers or pull images.
+var heavyRequestSuffixes = []string{
+	"_definitions/create",
+	"_definitions/create_custom",
+	"/check_connection",
+	"/check_connection_for_update",
+	"/discover_schema",
+}
+
+// Limiter caps the number of requests in flight and spaces requests out to a maximum rate. A nil
+// Limiter doesn't limit anything.
+type Limiter struct {
+	sem      chan struct{}
+	interval time.Duration
+
+	mu   sync.Mutex
+	next time.Time
+}
+
+// NewLimiter returns a Limiter; a maxConcurrent or perSecond of 0 disables that limit.
+func NewLimiter(maxConcurrent int, perSecond float64) *Limiter {
+	if maxConcurrent <= 0 && perSecond <= 0 {
+		return nil
+	}
+
+	l := &Limiter{}
+	if maxConcurrent > 0 {
+		l.sem = make(chan struct{}, maxConcurrent)
+	}
+	if perSecond > 0 {
+		l.interval = time.Duration(float64(time.Second) / perSecond)
+	}
+
+	return l
+}
+
+// Acquire blocks until a request may be sent. The returned function must be called once the
+// request is done.
+func (l *Limiter) Acquire(ctx context.Context) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+
+	if l.sem != nil {
+		select {
+		case l.sem <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	release := func() {
+		if l.sem != nil {
+			<-l.sem
+		}
+	}
+
+	if l.interval > 0 {
+		l.mu.Lock()
+		now := time.Now()
+		slot := l.next
+		if slot.Before(now) {
+			slot = now
+		}
+		l.next = slot.Add(l.interval)
+		l.mu.Unlock()
+
+		if wait := time.Until(slot); wait > 0 {
+			select {
+			case <-time.After(wait):
+			case <-ctx.Done():
+				release()
+				return nil, ctx.Err()
+			}
+		}
+	}
+
+	return release, nil
+}
+
+func isHeavyRequest(req *http.Request) bool {
+	for _, suffix := range heavyRequestSuffixes {
+		if strings.HasSuffix(req.URL.Path, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// acquire takes a slot from the general limiter and, for heavyweight operations, from the heavy one
+// first. Heavy requests waiting for their turn then don't hold general slots that other requests
+// could use.
+func (c *ApiClient) acquire(req *http.Request) (func(), error) {
+	if !isHeavyRequest(req) {
+		return c.Limiter.Acquire(req.Context())
+	}
+
+	releaseHeavy, err := c.HeavyLimiter.Acquire(req.Context())
+	if err != nil {
+		return nil, err
+	}
+	release, err := c.Limiter.Acquire(req.Context())
+	if err != nil {
+		releaseHeavy()
+		return nil, err
+	}
+
+	return func() {
+		release()
+		releaseHeavy()
+	}, nil
+}
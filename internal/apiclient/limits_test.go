@@ -0,0 +1,147 @@
+package apiclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLimiter_capsConcurrency(t *testing.T) {
+	var inFlight, maxInFlight int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		for {
+			m := atomic.LoadInt32(&maxInFlight)
+			if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+		fmt.Fprint(w, `{"sourceDefinitionId":"abc"}`)
+	}))
+	defer srv.Close()
+
+	c := &ApiClient{
+		HostURL:      srv.URL,
+		HTTPClient:   srv.Client(),
+		Limiter:      NewLimiter(4, 0),
+		HeavyLimiter: NewLimiter(1, 0),
+	}
+
+	run := func(f func() error) int32 {
+		atomic.StoreInt32(&maxInFlight, 0)
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if err := f(); err != nil {
+					t.Error(err)
+				}
+			}()
+		}
+		wg.Wait()
+		return atomic.LoadInt32(&maxInFlight)
+	}
+
+	if got := run(func() error {
+		_, err := c.GetSourceDefinitionById(context.Background(), "abc")
+		return err
+	}); got > 4 {
+		t.Errorf("expected at most 4 reads in flight, got %d", got)
+	}
+
+	if got := run(func() error {
+		_, err := c.CreateSourceDefinition(context.Background(), SourceDefinitionCreate{})
+		return err
+	}); got > 1 {
+		t.Errorf("expected at most 1 definition creation in flight, got %d", got)
+	}
+}
+
+func TestLimiter_heavyRequestsLeaveRoomForReads(t *testing.T) {
+	unblock := make(chan struct{})
+	heavyStarted := make(chan struct{}, 10)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/source_definitions/create" {
+			heavyStarted <- struct{}{}
+			<-unblock
+		}
+		fmt.Fprint(w, `{"sourceDefinitionId":"abc"}`)
+	}))
+	defer srv.Close()
+
+	c := &ApiClient{
+		HostURL:      srv.URL,
+		HTTPClient:   srv.Client(),
+		Limiter:      NewLimiter(2, 0),
+		HeavyLimiter: NewLimiter(1, 0),
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.CreateSourceDefinition(context.Background(), SourceDefinitionCreate{}); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	defer wg.Wait()
+	defer close(unblock)
+
+	<-heavyStarted
+	// Give the other heavy requests time to queue up behind the one in flight.
+	time.Sleep(20 * time.Millisecond)
+
+	read := make(chan error, 1)
+	go func() {
+		_, err := c.GetSourceDefinitionById(context.Background(), "abc")
+		read <- err
+	}()
+
+	select {
+	case err := <-read:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("read was blocked behind queued heavy requests")
+	}
+}
+
+func TestLimiter_rate(t *testing.T) {
+	l := NewLimiter(0, 100)
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		release, err := l.Acquire(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		release()
+	}
+	if elapsed := time.Since(start); elapsed < 35*time.Millisecond {
+		t.Errorf("expected 5 requests at 100/s to take at least 40ms, took %s", elapsed)
+	}
+}
+
+func TestLimiter_nilIsUnlimited(t *testing.T) {
+	if l := NewLimiter(0, 0); l != nil {
+		t.Fatal("expected a nil limiter")
+	}
+
+	var l *Limiter
+	release, err := l.Acquire(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	release()
+}
@@ -0,0 +1,222 @@
+package apiclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// LogSubsystem is the tflog subsystem API calls are logged under.
+const LogSubsystem = "airbyte_api"
+
+// LogLevelEnvVar sets the level of LogSubsystem on its own. When unset, it logs at the provider's level.
+const LogLevelEnvVar = "TF_LOG_PROVIDER_AIRBYTE_API"
+
+// RedactedValue is what secrets are replaced with; it matches the mask Airbyte itself uses.
+const RedactedValue = "**********"
+
+// secretKeys are keys whose values are always masked, whatever connector they belong to.
+var secretKeys = map[string]bool{
+	"password":             true,
+	"client_secret":        true,
+	"access_token":         true,
+	"refresh_token":        true,
+	"api_key":              true,
+	"apikey":               true,
+	"api_token":            true,
+	"token":                true,
+	"secret":               true,
+	"secret_key":           true,
+	"private_key":          true,
+	"credentials_json":     true,
+	"service_account_info": true,
+	"webhook":              true,
+}
+
+// schemaKeys hold JSON schemas, which name secret fields but never contain their values.
+var schemaKeys = map[string]bool{
+	"connectionSpecification": true,
+	"jsonSchema":              true,
+}
+
+var secretHeaderPattern = regexp.MustCompile(`(?i)authorization|cookie|secret|token|key`)
+
+var pathIndex = regexp.MustCompile(`\[\d+\]$`)
+
+// secretFieldSet holds the names of fields marked `airbyte_secret` in the connector specs seen so far.
+type secretFieldSet struct {
+	mu    sync.RWMutex
+	names map[string]bool
+}
+
+// RegisterSecretFields marks field names whose values must be masked in logs and diagnostics.
+func (c *ApiClient) RegisterSecretFields(names ...string) {
+	c.secretFields.add(names...)
+}
+
+func (c *ApiClient) isSecretKey(key string) bool {
+	return c.secretFields.isSecret(key)
+}
+
+// redactBody masks every secret in a JSON body. Bodies that aren't JSON are returned unchanged.
+func (c *ApiClient) redactBody(body []byte) string {
+	return c.secretFields.redactBody(body)
+}
+
+func (s *secretFieldSet) add(names ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.names == nil {
+		s.names = make(map[string]bool)
+	}
+	for _, name := range names {
+		s.names[name] = true
+	}
+}
+
+func (s *secretFieldSet) isSecret(key string) bool {
+	if secretKeys[strings.ToLower(key)] {
+		return true
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.names[key]
+}
+
+func (s *secretFieldSet) redactBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	var v any
+	if err := json.Unmarshal(body, &v); err != nil {
+		return string(body)
+	}
+
+	redacted, err := json.Marshal(s.redactValue(v))
+	if err != nil {
+		return string(body)
+	}
+
+	return string(redacted)
+}
+
+func (s *secretFieldSet) redactValue(v any) any {
+	switch val := v.(type) {
+	case map[string]any:
+		for k, child := range val {
+			if schemaKeys[k] {
+				continue
+			}
+			// Secrets aren't always strings: ports, key files and credential objects are masked whole.
+			if child != nil && s.isSecret(k) {
+				val[k] = RedactedValue
+			} else {
+				val[k] = s.redactValue(child)
+			}
+		}
+	case []any:
+		for i, child := range val {
+			val[i] = s.redactValue(child)
+		}
+	}
+
+	return v
+}
+
+func (c *ApiClient) redactAPIError(apiErr *APIError) {
+	apiErr.Body = c.redactBody([]byte(apiErr.Body))
+
+	for i, ve := range apiErr.ValidationErrors {
+		segments := strings.Split(ve.PropertyPath, ".")
+		leaf := pathIndex.ReplaceAllString(segments[len(segments)-1], "")
+		if c.isSecretKey(leaf) && ve.InvalidValue != "" {
+			apiErr.Body = strings.ReplaceAll(apiErr.Body, ve.InvalidValue, RedactedValue)
+			apiErr.ValidationErrors[i].InvalidValue = RedactedValue
+		}
+	}
+}
+
+func redactHeaders(header http.Header) map[string]string {
+	headers := make(map[string]string, len(header))
+	for k := range header {
+		if secretHeaderPattern.MatchString(k) {
+			headers[k] = RedactedValue
+		} else {
+			headers[k] = header.Get(k)
+		}
+	}
+	return headers
+}
+
+// secretFieldsFromSpec collects the names of all properties marked `airbyte_secret` in a connector
+// specification, including the ones nested in oneOf/anyOf options.
+func secretFieldsFromSpec(spec any) []string {
+	var names []string
+
+	switch node := spec.(type) {
+	case map[string]any:
+		if props, ok := node["properties"].(map[string]any); ok {
+			for name, prop := range props {
+				if p, ok := prop.(map[string]any); ok && p["airbyte_secret"] == true {
+					names = append(names, name)
+				}
+			}
+		}
+		for _, child := range node {
+			names = append(names, secretFieldsFromSpec(child)...)
+		}
+	case []any:
+		for _, child := range node {
+			names = append(names, secretFieldsFromSpec(child)...)
+		}
+	}
+
+	return names
+}
+
+// observeResponse registers the secret fields of the connector specifications the client reads, so
+// they are masked in logs and diagnostics from then on.
+func (c *ApiClient) observeResponse(out any) {
+	switch res := out.(type) {
+	case *SourceDefinitionSpecificationRead:
+		c.RegisterSecretFields(secretFieldsFromSpec(res.ConnectionSpecification)...)
+	case *DestinationDefinitionSpecificationRead:
+		c.RegisterSecretFields(secretFieldsFromSpec(res.ConnectionSpecification)...)
+	}
+}
+
+// WithLogSubsystem returns ctx with the LogSubsystem logger, at the level set by LogLevelEnvVar.
+// Terraform hands every provider call a new context, so the provider sets it up once when it is
+// configured and the client once per API call.
+func WithLogSubsystem(ctx context.Context) context.Context {
+	return tflog.NewSubsystem(ctx, LogSubsystem, tflog.WithLevelFromEnv(LogLevelEnvVar))
+}
+
+func (c *ApiClient) logRequest(ctx context.Context, req *http.Request, reqBody []byte) {
+	tflog.SubsystemDebug(ctx, LogSubsystem, "Sending Airbyte API request", map[string]any{
+		"method":   req.Method,
+		"endpoint": req.URL.Path,
+		"headers":  redactHeaders(req.Header),
+		"body":     c.redactBody(reqBody),
+	})
+}
+
+func (c *ApiClient) logResponse(ctx context.Context, req *http.Request, res *http.Response, resBody []byte, start time.Time) {
+	tflog.SubsystemDebug(ctx, LogSubsystem, "Received Airbyte API response", map[string]any{
+		"method":     req.Method,
+		"endpoint":   req.URL.Path,
+		"status":     res.StatusCode,
+		"latency_ms": time.Since(start).Milliseconds(),
+		"body":       c.redactBody(resBody),
+	})
+}
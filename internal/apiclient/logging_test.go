@@ -0,0 +1,100 @@
+package apiclient
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestRedactBody(t *testing.T) {
+	c := &ApiClient{}
+	c.RegisterSecretFields("personal_access_token")
+
+	body := []byte(`{"name":"gh","connectionConfiguration":{"repository":"a/b","personal_access_token":"ghp_123","nested":[{"password":"hunter2"}],"api_key":42424242,"credentials_json":{"private_key_id":"pk_987"}}}`)
+	redacted := c.redactBody(body)
+
+	for _, secret := range []string{"ghp_123", "hunter2", "42424242", "pk_987"} {
+		if strings.Contains(redacted, secret) {
+			t.Errorf("%s leaked in %s", secret, redacted)
+		}
+	}
+	if !strings.Contains(redacted, "a/b") {
+		t.Errorf("non-secret value was masked: %s", redacted)
+	}
+
+	spec := []byte(`{"connectionSpecification":{"properties":{"password":{"type":"string","airbyte_secret":true}}}}`)
+	if redacted := c.redactBody(spec); !strings.Contains(redacted, "airbyte_secret") {
+		t.Errorf("specification was masked: %s", redacted)
+	}
+}
+
+func TestSecretFieldsFromSpec(t *testing.T) {
+	spec := map[string]any{
+		"properties": map[string]any{
+			"start_date": map[string]any{"type": "string"},
+			"credentials": map[string]any{
+				"oneOf": []any{
+					map[string]any{"properties": map[string]any{
+						"client_secret": map[string]any{"type": "string", "airbyte_secret": true},
+					}},
+					map[string]any{"properties": map[string]any{
+						"personal_access_token": map[string]any{"type": "string", "airbyte_secret": true},
+					}},
+				},
+			},
+		},
+	}
+
+	names := secretFieldsFromSpec(spec)
+	if len(names) != 2 {
+		t.Fatalf("expected 2 secret fields, got %v", names)
+	}
+}
+
+func TestAPIError_redactsInvalidValues(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		fmt.Fprint(w, `{"message":"invalid","validationErrors":[{"propertyPath":"connectionConfiguration.api_key","invalidValue":"sk_live_1","message":"bad"}]}`)
+	}))
+	defer srv.Close()
+
+	c := &ApiClient{HostURL: srv.URL, HTTPClient: srv.Client()}
+	_, err := c.CreateSource(context.Background(), SourceCreate{})
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected an APIError, got %v", err)
+	}
+	if strings.Contains(apiErr.Body, "sk_live_1") || apiErr.ValidationErrors[0].InvalidValue != RedactedValue {
+		t.Errorf("secret leaked into the error: %+v", apiErr)
+	}
+}
+
+func TestLogLevelEnvVar(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"available":true}`)
+	}))
+	defer srv.Close()
+
+	c := &ApiClient{HostURL: srv.URL, HTTPClient: srv.Client()}
+
+	for level, expectLogs := range map[string]bool{"": true, "DEBUG": true, "WARN": false} {
+		t.Setenv(LogLevelEnvVar, level)
+
+		var output bytes.Buffer
+		if _, err := c.GetHealthCheck(tflogtest.RootLogger(context.Background(), &output)); err != nil {
+			t.Fatal(err)
+		}
+
+		if logged := strings.Contains(output.String(), "Sending Airbyte API request"); logged != expectLogs {
+			t.Errorf("%s=%q: expected request logs %v, got %q", LogLevelEnvVar, level, expectLogs, output.String())
+		}
+	}
+}
@@ -0,0 +1,238 @@
+package apiclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// RecorderMode selects whether a Recorder talks to a real server or plays back a cassette.
+type RecorderMode string
+
+const (
+	// RecordMode sends requests to the wrapped transport and captures them in the cassette.
+	RecordMode RecorderMode = "record"
+	// ReplayMode answers requests from the cassette without touching the network.
+	ReplayMode RecorderMode = "replay"
+)
+
+// Cassette is the on-disk form of a recording.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is one request/response pair. Requests are identified by method, path and body;
+// the host is left out so a recording can be replayed against any URL.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+type RecordedRequest struct {
+	Method  string            `json:"method"`
+	Path    string            `json:"path"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    string            `json:"body,omitempty"`
+}
+
+type RecordedResponse struct {
+	StatusCode int               `json:"statusCode"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	Body       string            `json:"body,omitempty"`
+}
+
+// Recorder is an http.RoundTripper that records API calls to a cassette file, or replays them from
+// one. Credentials and connector secrets are scrubbed from requests and responses before anything is
+// written, including the fields marked `airbyte_secret` in connector specifications that pass through
+// it. Install it as the transport of ApiClient.HTTPClient, and call Save once the
+// recording is complete.
+type Recorder struct {
+	Mode RecorderMode
+	// Path is the cassette file.
+	Path string
+	// Transport makes the real requests in RecordMode; http.DefaultTransport when nil.
+	Transport http.RoundTripper
+
+	mu       sync.Mutex
+	cassette Cassette
+	replayed []bool
+	secrets  secretFieldSet
+}
+
+// NewRecorder returns a Recorder for the cassette at path, loading it when replaying.
+func NewRecorder(path string, mode RecorderMode, transport http.RoundTripper) (*Recorder, error) {
+	r := &Recorder{Mode: mode, Path: path, Transport: transport}
+
+	switch mode {
+	case RecordMode:
+	case ReplayMode:
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read cassette: %w", err)
+		}
+		if err := json.Unmarshal(data, &r.cassette); err != nil {
+			return nil, fmt.Errorf("unable to parse cassette %s: %w", path, err)
+		}
+		r.replayed = make([]bool, len(r.cassette.Interactions))
+		for _, i := range r.cassette.Interactions {
+			r.learnSecrets(i.Response.Body)
+		}
+	default:
+		return nil, fmt.Errorf("unknown recorder mode %q", mode)
+	}
+
+	return r, nil
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil {
+		var err error
+		reqBody, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	recorded := RecordedRequest{
+		Method:  req.Method,
+		Path:    req.URL.Path,
+		Headers: redactHeaders(req.Header),
+		Body:    scrub(&r.secrets, req.Header.Get("Content-Type"), reqBody),
+	}
+
+	if r.Mode == ReplayMode {
+		return r.replay(req, recorded)
+	}
+
+	req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	res, err := r.transport().RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resBody, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(resBody))
+
+	r.learnSecrets(string(resBody))
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request: recorded,
+		Response: RecordedResponse{
+			StatusCode: res.StatusCode,
+			Headers:    redactHeaders(res.Header),
+			Body:       scrub(&r.secrets, res.Header.Get("Content-Type"), resBody),
+		},
+	})
+	r.mu.Unlock()
+
+	return res, nil
+}
+
+// Save writes the cassette when recording; it does nothing when replaying.
+func (r *Recorder) Save() error {
+	if r.Mode != RecordMode {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.Path), 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(r.Path, append(data, '\n'), 0o644)
+}
+
+// replay answers with the first interaction not replayed yet that has the same method, path and
+// (scrubbed) body, so concurrent requests can't take each other's responses.
+func (r *Recorder) replay(req *http.Request, recorded RecordedRequest) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.cassette.Interactions {
+		if r.replayed[i] || !interaction.Request.matches(recorded) {
+			continue
+		}
+		r.replayed[i] = true
+
+		header := make(http.Header, len(interaction.Response.Headers))
+		for k, v := range interaction.Response.Headers {
+			header.Set(k, v)
+		}
+
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(strings.NewReader(interaction.Response.Body)),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("no interaction recorded in %s for %s %s with body %s", r.Path, recorded.Method, recorded.Path, recorded.Body)
+}
+
+func (r RecordedRequest) matches(other RecordedRequest) bool {
+	return r.Method == other.Method && r.Path == other.Path && r.Body == other.Body
+}
+
+func (r *Recorder) transport() http.RoundTripper {
+	if r.Transport == nil {
+		return http.DefaultTransport
+	}
+	return r.Transport
+}
+
+// scrub masks the secrets in a JSON or form-encoded body, such as an OAuth token request.
+func scrub(secrets *secretFieldSet, contentType string, body []byte) string {
+	if !strings.HasPrefix(contentType, "application/x-www-form-urlencoded") {
+		return secrets.redactBody(body)
+	}
+
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		return RedactedValue
+	}
+	for k := range form {
+		if secrets.isSecret(k) {
+			form.Set(k, RedactedValue)
+		}
+	}
+
+	return form.Encode()
+}
+
+// learnSecrets registers the secret fields of any connector specification in a response body, so
+// later configurations using that connector are scrubbed too.
+func (r *Recorder) learnSecrets(body string) {
+	var v map[string]any
+	if err := json.Unmarshal([]byte(body), &v); err != nil {
+		return
+	}
+	if spec, ok := v["connectionSpecification"]; ok {
+		r.secrets.add(secretFieldsFromSpec(spec)...)
+	}
+}
@@ -0,0 +1,97 @@
+package apiclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecorder_recordAndReplay(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/source_definition_specifications/get":
+			fmt.Fprint(w, `{"sourceDefinitionId":"sd","connectionSpecification":{"properties":{"personal_access_token":{"type":"string","airbyte_secret":true}}}}`)
+		case "/api/v1/sources/create":
+			fmt.Fprint(w, `{"sourceId":"s1","name":"gh","connectionConfiguration":{"repository":"a/b","personal_access_token":"**********"}}`)
+		case "/api/v1/workspaces/get":
+			fmt.Fprint(w, `{"workspaceId":"ws","name":"w","notifications":[{"notificationType":"slack","sendOnSuccess":false,"sendOnFailure":true,"slackConfiguration":{"webhook":"https://hooks.slack.com/services/T/B/X"}}]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	newSource := SourceCreate{
+		Name:                    "gh",
+		ConnectionConfiguration: map[string]any{"repository": "a/b", "personal_access_token": "ghp_123"},
+	}
+
+	rec, err := NewRecorder(path, RecordMode, srv.Client().Transport)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &ApiClient{HostURL: srv.URL, HTTPClient: &http.Client{Transport: rec}, Username: "airbyte", Password: "hunter2"}
+	if _, err := c.GetSourceDefinitionSpecification(context.Background(), SourceDefinitionIdWithWorkspaceId{SourceDefinitionId: "sd"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.CreateSource(context.Background(), newSource); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetWorkspaceById(context.Background(), "ws"); err != nil {
+		t.Fatal(err)
+	}
+	if err := rec.Save(); err != nil {
+		t.Fatal(err)
+	}
+	srv.Close()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"ghp_123", "hunter2", "YWlyYnl0ZTpodW50ZXIy", "hooks.slack.com"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("%s leaked into the cassette:\n%s", secret, data)
+		}
+	}
+
+	rec, err = NewRecorder(path, ReplayMode, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c = &ApiClient{HostURL: "http://replay.invalid", HTTPClient: &http.Client{Transport: rec}}
+	if _, err := c.GetSourceDefinitionSpecification(context.Background(), SourceDefinitionIdWithWorkspaceId{SourceDefinitionId: "sd"}); err != nil {
+		t.Fatal(err)
+	}
+	s, err := c.CreateSource(context.Background(), newSource)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.SourceId != "s1" {
+		t.Fatalf("unexpected replayed source %+v", s)
+	}
+	w, err := c.GetWorkspaceById(context.Background(), "ws")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if webhook := w.Notifications[0].SlackConfiguration.Webhook; webhook != RedactedValue {
+		t.Fatalf("expected the webhook to replay masked, got %q", webhook)
+	}
+
+	// Every interaction is replayed once.
+	if _, err := c.CreateSource(context.Background(), newSource); err == nil || !strings.Contains(err.Error(), "no interaction recorded") {
+		t.Fatalf("expected a missing interaction error, got %v", err)
+	}
+}
+
+func TestRecorder_scrubsFormBodies(t *testing.T) {
+	scrubbed := scrub(&secretFieldSet{}, "application/x-www-form-urlencoded", []byte("grant_type=client_credentials&client_id=id&client_secret=s3cret"))
+	if strings.Contains(scrubbed, "s3cret") || !strings.Contains(scrubbed, "client_id=id") {
+		t.Fatalf("unexpected scrubbed body %s", scrubbed)
+	}
+}
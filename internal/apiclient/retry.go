@@ -0,0 +1,106 @@
+package apiclient
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultMaxRetries   = 3
+	DefaultRetryMaxWait = 30 * time.Second
+	retryBaseWait       = 500 * time.Millisecond
+)
+
+// readRequestSuffixes are the endpoints that never change server state, and so are always safe to
+// retry and leave the ReadCache alone. Discovering a schema only refreshes the catalog Airbyte caches.
+var readRequestSuffixes = []string{"/get", "/list", "/get_by_slug", "/health", "/discover_schema"}
+
+// transportError wraps failures to reach the server at all (connection refused, reset, ...).
+type transportError struct {
+	err error
+}
+
+func (e *transportError) Error() string {
+	return e.err.Error()
+}
+
+func (e *transportError) Unwrap() error {
+	return e.err
+}
+
+type noRetriesKey struct{}
+
+// withoutRetries makes the requests sent with ctx give up after the first attempt, for callers that
+// pace their own attempts.
+func withoutRetries(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noRetriesKey{}, true)
+}
+
+func retriesDisabled(ctx context.Context) bool {
+	disabled, _ := ctx.Value(noRetriesKey{}).(bool)
+	return disabled
+}
+
+func isReadRequest(req *http.Request) bool {
+	if req.Method == http.MethodGet {
+		return true
+	}
+	for _, suffix := range readRequestSuffixes {
+		if strings.HasSuffix(req.URL.Path, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+func isRetryable(err error) bool {
+	var te *transportError
+	if errors.As(err, &te) {
+		return true
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusTooManyRequests ||
+			(apiErr.StatusCode >= http.StatusInternalServerError && apiErr.StatusCode != http.StatusNotImplemented)
+	}
+
+	return false
+}
+
+// retryAfter reads the Retry-After header that Airbyte (or the proxy in front of it) may send with a 429 or 503.
+func retryAfter(res *http.Response) time.Duration {
+	if v := res.Header.Get("Retry-After"); v != "" {
+		if seconds, err := strconv.Atoi(v); err == nil && seconds > 0 {
+			return time.Duration(seconds) * time.Second
+		}
+	}
+	return 0
+}
+
+// backoff returns an exponential, fully jittered wait for the given attempt, capped at RetryMaxWait.
+func (c *ApiClient) backoff(attempt int, retryAfter time.Duration) time.Duration {
+	maxWait := c.RetryMaxWait
+	if maxWait <= 0 {
+		maxWait = DefaultRetryMaxWait
+	}
+
+	if retryAfter > 0 {
+		if retryAfter > maxWait {
+			return maxWait
+		}
+		return retryAfter
+	}
+
+	wait := retryBaseWait << attempt
+	if wait <= 0 || wait > maxWait {
+		wait = maxWait
+	}
+
+	return time.Duration(rand.Int63n(int64(wait)) + 1)
+}
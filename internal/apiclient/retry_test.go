@@ -0,0 +1,112 @@
+package apiclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newFlakyServer(failures int32, status int, calls *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(calls, 1) <= failures {
+			w.WriteHeader(status)
+			return
+		}
+		fmt.Fprint(w, `{"workspaceId":"abc"}`)
+	}))
+}
+
+func TestDoRequest_retriesReads(t *testing.T) {
+	var calls int32
+	srv := newFlakyServer(2, http.StatusBadGateway, &calls)
+	defer srv.Close()
+
+	c := &ApiClient{HostURL: srv.URL, HTTPClient: srv.Client(), MaxRetries: 3, RetryMaxWait: time.Millisecond}
+
+	if _, err := c.GetWorkspaceById(context.Background(), "abc"); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 3 {
+		t.Fatalf("expected 3 calls, got %d", calls)
+	}
+}
+
+func TestDoRequest_givesUpAfterMaxRetries(t *testing.T) {
+	var calls int32
+	srv := newFlakyServer(10, http.StatusServiceUnavailable, &calls)
+	defer srv.Close()
+
+	c := &ApiClient{HostURL: srv.URL, HTTPClient: srv.Client(), MaxRetries: 2, RetryMaxWait: time.Millisecond}
+
+	if _, err := c.GetWorkspaceById(context.Background(), "abc"); err == nil {
+		t.Fatal("expected an error")
+	}
+	if calls != 3 {
+		t.Fatalf("expected 3 calls, got %d", calls)
+	}
+}
+
+func TestDoRequest_doesNotRetryWritesByDefault(t *testing.T) {
+	var calls int32
+	srv := newFlakyServer(2, http.StatusServiceUnavailable, &calls)
+	defer srv.Close()
+
+	c := &ApiClient{HostURL: srv.URL, HTTPClient: srv.Client(), MaxRetries: 3, RetryMaxWait: time.Millisecond}
+
+	if _, err := c.CreateWorkspace(context.Background(), WorkspaceCreate{}); err == nil {
+		t.Fatal("expected an error")
+	}
+	if calls != 1 {
+		t.Fatalf("expected 1 call, got %d", calls)
+	}
+
+	c.RetryMutatingRequests = true
+	if _, err := c.CreateWorkspace(context.Background(), WorkspaceCreate{}); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 3 {
+		t.Fatalf("expected 3 calls, got %d", calls)
+	}
+}
+
+func TestDoRequest_doesNotRetryClientErrors(t *testing.T) {
+	var calls int32
+	srv := newFlakyServer(10, http.StatusUnprocessableEntity, &calls)
+	defer srv.Close()
+
+	c := &ApiClient{HostURL: srv.URL, HTTPClient: srv.Client(), MaxRetries: 3, RetryMaxWait: time.Millisecond}
+
+	if _, err := c.GetWorkspaceById(context.Background(), "abc"); err == nil {
+		t.Fatal("expected an error")
+	}
+	if calls != 1 {
+		t.Fatalf("expected 1 call, got %d", calls)
+	}
+}
+
+func TestDoRequest_requestTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+		switch r.URL.Path {
+		case "/api/v1/deployment/metadata":
+			fmt.Fprint(w, `{"version":"0.40.0"}`)
+		case "/api/v1/sources/discover_schema":
+			fmt.Fprint(w, `{"catalog":{"streams":[]},"jobInfo":{"id":"j1","configType":"discover_schema","createdAt":1,"endedAt":2,"succeeded":true}}`)
+		}
+	}))
+	defer srv.Close()
+
+	c := &ApiClient{HostURL: srv.URL, HTTPClient: srv.Client(), RequestTimeout: 10 * time.Millisecond}
+	ctx := context.Background()
+
+	if err := c.DetectServerVersion(ctx); err == nil {
+		t.Fatal("expected the request to time out")
+	}
+	if _, err := c.DiscoverSourceSchema(ctx, "s1", false); err != nil {
+		t.Fatalf("expected discovering to be bounded by the context only, got %v", err)
+	}
+}
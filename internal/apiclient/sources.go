@@ -1,131 +1,69 @@
 package apiclient
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"strings"
 )
 
-type SourceIdBody struct {
-	SourceId string `json:"sourceId"`
-}
-
-type CommonSourceFields struct {
-	Name                    string         `json:"name"`
-	ConnectionConfiguration map[string]any `json:"connectionConfiguration"`
-}
-
-type Source struct {
-	SourceIdBody
-	SourceDefinitionIdBody
-	WorkspaceIdBody
-	CommonSourceFields
-	SourceName string `json:"sourceName"`
-	Icon       string `json:"icon"`
-}
-
-type NewSource struct {
-	SourceDefinitionIdBody
-	WorkspaceIdBody
-	CommonSourceFields
-}
-
-type UpdatedSource struct {
-	SourceIdBody
-	CommonSourceFields
-}
-
-func (c *ApiClient) GetSourceById(sourceId string) (*Source, error) {
-	rb, err := json.Marshal(SourceIdBody{SourceId: sourceId})
-	if err != nil {
-		return nil, err
-	}
-
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/%s/sources/get", c.HostURL, BASE_URL), strings.NewReader(string(rb)))
-	if err != nil {
-		return nil, err
+// GetSourceById reads a source, from the ReadCache when there is one. A read that misses the cache
+// fills it with the other sources of the same workspace.
+func (c *ApiClient) GetSourceById(ctx context.Context, sourceId string) (*SourceRead, error) {
+	if s := c.cachedSource(sourceId); s != nil {
+		return s, nil
 	}
 
-	body, err := c.doRequest(req)
+	s, err := c.GetSource(ctx, SourceIdRequestBody{SourceId: sourceId})
 	if err != nil {
 		return nil, err
 	}
 
-	s := Source{}
-	err = json.Unmarshal(body, &s)
-	if err != nil {
-		return nil, err
-	}
+	c.prefetchSources(ctx, s.WorkspaceId)
 
-	return &s, nil
+	return s, nil
 }
 
-func (c *ApiClient) CreateSource(newSource NewSource) (*Source, error) {
-	rb, err := json.Marshal(newSource)
-	if err != nil {
-		return nil, err
-	}
-
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/%s/sources/create", c.HostURL, BASE_URL), strings.NewReader(string(rb)))
-	if err != nil {
-		return nil, err
-	}
-
-	body, err := c.doRequest(req)
-	if err != nil {
-		return nil, err
-	}
-
-	s := Source{}
-	err = json.Unmarshal(body, &s)
-	if err != nil {
-		return nil, err
-	}
-
-	return &s, nil
+// DiscoveredSchema is the result of a successful discover job.
+type DiscoveredSchema struct {
+	*SourceDiscoverSchemaRead
+	// CatalogJSON is the catalog as the server sent it, including the fields this client doesn't know.
+	CatalogJSON json.RawMessage
 }
 
-func (c *ApiClient) UpdateSource(updatedSource UpdatedSource) (*Source, error) {
-	rb, err := json.Marshal(updatedSource)
-	if err != nil {
-		return nil, err
+// DiscoverSourceSchema runs a discover job for the source, or returns the catalog Airbyte cached
+// from the last one unless disableCache is set. A failed job is still answered with a 200, so it is
+// turned into an error here.
+func (c *ApiClient) DiscoverSourceSchema(ctx context.Context, sourceId string, disableCache bool) (*DiscoveredSchema, error) {
+	body := SourceDiscoverSchemaRequestBody{SourceId: sourceId}
+	if disableCache {
+		body.DisableCache = &disableCache
 	}
 
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/%s/sources/update", c.HostURL, BASE_URL), strings.NewReader(string(rb)))
+	raw, err := c.callRaw(ctx, http.MethodPost, "sources/discover_schema", body)
 	if err != nil {
 		return nil, err
 	}
-
-	body, err := c.doRequest(req)
-	if err != nil {
+	r := &SourceDiscoverSchemaRead{}
+	if err := json.Unmarshal(raw, r); err != nil {
 		return nil, err
 	}
-
-	s := Source{}
-	err = json.Unmarshal(body, &s)
-	if err != nil {
-		return nil, err
-	}
-
-	return &s, nil
-}
-
-func (c *ApiClient) DeleteSource(sourceId string) error {
-	rb, err := json.Marshal(SourceIdBody{SourceId: sourceId})
-	if err != nil {
-		return err
+	var fields struct {
+		Catalog json.RawMessage `json:"catalog"`
 	}
-
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/%s/sources/delete", c.HostURL, BASE_URL), strings.NewReader(string(rb)))
-	if err != nil {
-		return err
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, err
 	}
 
-	_, err = c.doRequest(req)
-	if err != nil {
-		return err
+	if !r.JobInfo.Succeeded || r.Catalog == nil {
+		reason := "no catalog was returned"
+		if f := r.JobInfo.FailureReason; f != nil && f.ExternalMessage != "" {
+			reason = f.ExternalMessage
+		} else if f != nil && f.InternalMessage != "" {
+			reason = f.InternalMessage
+		}
+		return nil, fmt.Errorf("discovering the schema of source %s failed: %s", sourceId, reason)
 	}
 
-	return nil
+	return &DiscoveredSchema{SourceDiscoverSchemaRead: r, CatalogJSON: fields.Catalog}, nil
 }
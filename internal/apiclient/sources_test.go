@@ -0,0 +1,78 @@
+package apiclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDiscoverSourceSchema(t *testing.T) {
+	// isResumable is newer than the vendored spec, so only the raw catalog has it.
+	catalog := `{"streams": [{"stream": {"name": "users", "supportedSyncModes": ["full_refresh"], "isResumable": true}}]}`
+	var requests []map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/sources/discover_schema" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		body := map[string]any{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		requests = append(requests, body)
+
+		if body["disable_cache"] == true {
+			// Airbyte reports a failed discover job with a 200.
+			fmt.Fprint(w, `{"jobInfo":{"id":"j2","configType":"discover_schema","createdAt":1,"endedAt":2,"succeeded":false,"failureReason":{"timestamp":2,"externalMessage":"Unable to connect to the source"}}}`)
+			return
+		}
+		fmt.Fprintf(w, `{"catalog":%s,"catalogId":"c1","jobInfo":{"id":"j1","configType":"discover_schema","createdAt":1,"endedAt":2,"succeeded":true}}`, catalog)
+	}))
+	defer srv.Close()
+
+	c := &ApiClient{HostURL: srv.URL, HTTPClient: srv.Client()}
+
+	r, err := c.DiscoverSourceSchema(context.Background(), "s1", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.CatalogId != "c1" || len(r.Catalog.Streams) != 1 || r.Catalog.Streams[0].Stream.Name != "users" {
+		t.Fatalf("unexpected discovered schema %+v", r)
+	}
+	if string(r.CatalogJSON) != catalog {
+		t.Errorf("expected the catalog as it was sent, got %s", r.CatalogJSON)
+	}
+	if _, ok := requests[0]["disable_cache"]; ok {
+		t.Errorf("expected disable_cache to be left out, got %v", requests[0])
+	}
+
+	_, err = c.DiscoverSourceSchema(context.Background(), "s1", true)
+	if err == nil || !strings.Contains(err.Error(), "Unable to connect to the source") {
+		t.Fatalf("expected the failure reason of the job, got %v", err)
+	}
+}
+
+func TestDiscoverSourceSchema_retried(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		fmt.Fprint(w, `{"catalog":{"streams":[]},"catalogId":"c1","jobInfo":{"id":"j1","configType":"discover_schema","createdAt":1,"endedAt":2,"succeeded":true}}`)
+	}))
+	defer srv.Close()
+
+	c := &ApiClient{HostURL: srv.URL, HTTPClient: srv.Client(), MaxRetries: 3, RetryMaxWait: time.Millisecond}
+
+	if _, err := c.DiscoverSourceSchema(context.Background(), "s1", false); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 {
+		t.Fatalf("expected the discovery to be retried once, got %d calls", calls)
+	}
+}
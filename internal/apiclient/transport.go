@@ -0,0 +1,97 @@
+package apiclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// TransportConfig holds the connection-level settings of the http.Transport used by ApiClient.
+type TransportConfig struct {
+	// CACertFile and CACertPEM add CAs to the system pool when verifying the server certificate.
+	CACertFile string
+	CACertPEM  string
+	// ClientCert and ClientKey are used for mTLS. Each may be PEM content or a path to a PEM file.
+	ClientCert         string
+	ClientKey          string
+	InsecureSkipVerify bool
+	// ProxyURL overrides the HTTP(S)_PROXY environment variables.
+	ProxyURL string
+}
+
+func NewTransport(cfg TransportConfig) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+	}
+
+	if cfg.CACertFile != "" || cfg.CACertPEM != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+
+		if cfg.CACertFile != "" {
+			pem, err := os.ReadFile(cfg.CACertFile)
+			if err != nil {
+				return nil, fmt.Errorf("unable to read CA certificate file: %w", err)
+			}
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("no valid certificates found in %s", cfg.CACertFile)
+			}
+		}
+		if cfg.CACertPEM != "" {
+			if !pool.AppendCertsFromPEM([]byte(cfg.CACertPEM)) {
+				return nil, fmt.Errorf("no valid certificates found in the CA certificate PEM")
+			}
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	if cfg.ClientCert != "" || cfg.ClientKey != "" {
+		if cfg.ClientCert == "" || cfg.ClientKey == "" {
+			return nil, fmt.Errorf("both a client certificate and a client key are required for mTLS")
+		}
+
+		certPEM, err := pemOrFile(cfg.ClientCert)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read client certificate: %w", err)
+		}
+		keyPEM, err := pemOrFile(cfg.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read client key: %w", err)
+		}
+
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate or key: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	transport.TLSClientConfig = tlsConfig
+
+	if cfg.ProxyURL != "" {
+		proxyURL, err := url.Parse(cfg.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL: %w", err)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	return transport, nil
+}
+
+func pemOrFile(value string) ([]byte, error) {
+	if strings.Contains(value, "-----BEGIN") {
+		return []byte(value), nil
+	}
+	return os.ReadFile(value)
+}
@@ -0,0 +1,72 @@
+package apiclient
+
+import (
+	"context"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNewTransport_customCA(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"workspaceId":"abc"}`)
+	}))
+	defer srv.Close()
+
+	caPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw}))
+
+	cases := map[string]struct {
+		cfg     TransportConfig
+		wantErr bool
+	}{
+		"default trust store": {cfg: TransportConfig{}, wantErr: true},
+		"custom CA":           {cfg: TransportConfig{CACertPEM: caPEM}},
+		"insecure":            {cfg: TransportConfig{InsecureSkipVerify: true}},
+	}
+
+	for name, tc := range cases {
+		transport, err := NewTransport(tc.cfg)
+		if err != nil {
+			t.Fatalf("%s: %s", name, err)
+		}
+
+		c := &ApiClient{HostURL: srv.URL, HTTPClient: &http.Client{Transport: transport}}
+		_, err = c.GetWorkspaceById(context.Background(), "abc")
+		if tc.wantErr != (err != nil) {
+			t.Errorf("%s: expected error %v, got %v", name, tc.wantErr, err)
+		}
+	}
+}
+
+func TestNewTransport_rejectsHalfClientCert(t *testing.T) {
+	if _, err := NewTransport(TransportConfig{ClientCert: "cert.pem"}); err == nil {
+		t.Fatal("expected an error when only a client certificate is set")
+	}
+}
+
+func TestApiClient_headersAndBasePath(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/airbyte/api/v1/workspaces/get" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Header.Get("Cf-Access-Client-Id") != "abc" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		fmt.Fprint(w, `{"workspaceId":"abc"}`)
+	}))
+	defer srv.Close()
+
+	c := &ApiClient{
+		HostURL:    srv.URL + "/",
+		BasePath:   "/airbyte/api/v1/",
+		Headers:    map[string]string{"CF-Access-Client-Id": "abc"},
+		HTTPClient: srv.Client(),
+	}
+	if _, err := c.GetWorkspaceById(context.Background(), "abc"); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,55 @@
+package apiclient
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+)
+
+// Feature is a part of the API that only exists from a given Airbyte release on.
+type Feature struct {
+	Name       string
+	MinVersion string
+}
+
+var (
+	FeatureDefaultGeography     = Feature{Name: "Workspace default geography", MinVersion: "0.40.25"}
+	FeatureCustomDefinitions    = Feature{Name: "Workspace-scoped custom connector definitions", MinVersion: "0.40.17"}
+	FeatureCronSchedules        = Feature{Name: "Cron connection schedules", MinVersion: "0.40.0"}
+	FeatureConnectionGeography  = Feature{Name: "Connection geography", MinVersion: "0.40.25"}
+	FeatureFieldSelection       = Feature{Name: "Stream field selection", MinVersion: "0.43.0"}
+	FeatureSuccessNotifications = Feature{Name: "Notifications on successful syncs", MinVersion: "0.29.13"}
+)
+
+// DetectServerVersion reads the Airbyte version from the deployment metadata and stores it on the
+// client. Versions that aren't semver (e.g. `dev`) leave ServerVersion nil.
+func (c *ApiClient) DetectServerVersion(ctx context.Context) error {
+	dm, err := c.GetDeploymentMetadata(ctx)
+	if err != nil {
+		return err
+	}
+
+	c.RawServerVersion = dm.Version
+	c.ServerVersion, _ = version.NewVersion(strings.TrimPrefix(dm.Version, "v"))
+
+	return nil
+}
+
+// Supports reports whether the server has the feature. When the version is unknown, the server is
+// assumed to be recent.
+func (c *ApiClient) Supports(f Feature) bool {
+	if c.ServerVersion == nil {
+		return true
+	}
+	return c.ServerVersion.Core().GreaterThanOrEqual(version.Must(version.NewVersion(f.MinVersion)))
+}
+
+// RequireFeature returns an error naming the minimum version if the server lacks the feature.
+func (c *ApiClient) RequireFeature(f Feature) error {
+	if c.Supports(f) {
+		return nil
+	}
+	return fmt.Errorf("%s requires Airbyte >= %s, but the server is running %s", f.Name, f.MinVersion, c.RawServerVersion)
+}
@@ -0,0 +1,48 @@
+package apiclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDetectServerVersion(t *testing.T) {
+	cases := map[string]struct {
+		version  string
+		supports bool
+	}{
+		"old release":  {version: "0.40.10", supports: false},
+		"new release":  {version: "0.50.33", supports: true},
+		"v-prefixed":   {version: "v0.40.25", supports: true},
+		"prerelease":   {version: "0.40.25-alpha", supports: true},
+		"dev build":    {version: "dev", supports: true},
+		"exact minver": {version: FeatureDefaultGeography.MinVersion, supports: true},
+	}
+
+	for name, tc := range cases {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, `{"id":"1","mode":"OSS","version":%q}`, tc.version)
+		}))
+
+		c := &ApiClient{HostURL: srv.URL, HTTPClient: srv.Client()}
+		if err := c.DetectServerVersion(context.Background()); err != nil {
+			t.Fatalf("%s: %s", name, err)
+		}
+		if got := c.Supports(FeatureDefaultGeography); got != tc.supports {
+			t.Errorf("%s: expected Supports to be %v, got %v", name, tc.supports, got)
+		}
+
+		err := c.RequireFeature(FeatureDefaultGeography)
+		if tc.supports != (err == nil) {
+			t.Errorf("%s: unexpected RequireFeature result %v", name, err)
+		}
+		if err != nil && !strings.Contains(err.Error(), "requires Airbyte >= "+FeatureDefaultGeography.MinVersion) {
+			t.Errorf("%s: unexpected error message %q", name, err)
+		}
+
+		srv.Close()
+	}
+}
@@ -0,0 +1,155 @@
+package fakeairbyte
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// airbyteStream is the immutable half of a catalog stream, as discovered from the source.
+type airbyteStream struct {
+	Name                    string         `json:"name"`
+	Namespace               string         `json:"namespace,omitempty"`
+	JsonSchema              map[string]any `json:"jsonSchema"`
+	SupportedSyncModes      []string       `json:"supportedSyncModes"`
+	SourceDefinedCursor     bool           `json:"sourceDefinedCursor"`
+	DefaultCursorField      []string       `json:"defaultCursorField"`
+	SourceDefinedPrimaryKey [][]string     `json:"sourceDefinedPrimaryKey"`
+}
+
+type streamConfiguration struct {
+	SyncMode              string     `json:"syncMode"`
+	CursorField           []string   `json:"cursorField"`
+	DestinationSyncMode   string     `json:"destinationSyncMode"`
+	PrimaryKey            [][]string `json:"primaryKey"`
+	AliasName             string     `json:"aliasName"`
+	Selected              bool       `json:"selected"`
+	Suggested             bool       `json:"suggested"`
+	FieldSelectionEnabled bool       `json:"fieldSelectionEnabled"`
+	SelectedFields        []any      `json:"selectedFields"`
+}
+
+type catalogStream struct {
+	Stream *airbyteStream       `json:"stream"`
+	Config *streamConfiguration `json:"config"`
+}
+
+type catalog struct {
+	Streams []catalogStream `json:"streams"`
+}
+
+// stream builds a discovered stream whose properties are all strings. Streams with a cursor support
+// incremental syncs with a source defined cursor.
+func stream(name string, cursor string, primaryKey string, properties ...string) *airbyteStream {
+	props := map[string]any{}
+	for _, p := range properties {
+		props[p] = map[string]any{"type": []any{"null", "string"}}
+	}
+
+	s := &airbyteStream{
+		Name:                    name,
+		JsonSchema:              map[string]any{"type": "object", "properties": props},
+		SupportedSyncModes:      []string{"full_refresh"},
+		DefaultCursorField:      []string{},
+		SourceDefinedPrimaryKey: [][]string{},
+	}
+	if cursor != "" {
+		s.SupportedSyncModes = append(s.SupportedSyncModes, "incremental")
+		s.SourceDefinedCursor = true
+		s.DefaultCursorField = []string{cursor}
+	}
+	if primaryKey != "" {
+		s.SourceDefinedPrimaryKey = [][]string{{primaryKey}}
+	}
+
+	return s
+}
+
+// customConnectorStreams are discovered from every definition created through the API.
+var customConnectorStreams = []*airbyteStream{
+	stream("records", "", "", "id", "data"),
+}
+
+// discoveredCatalog pairs the streams with the configuration Airbyte suggests for them: a selected
+// full refresh appending to the destination.
+func discoveredCatalog(streams []*airbyteStream) catalog {
+	c := catalog{Streams: []catalogStream{}}
+	for _, s := range streams {
+		c.Streams = append(c.Streams, catalogStream{
+			Stream: s,
+			Config: &streamConfiguration{
+				SyncMode:            "full_refresh",
+				CursorField:         s.DefaultCursorField,
+				DestinationSyncMode: "append",
+				PrimaryKey:          s.SourceDefinedPrimaryKey,
+				AliasName:           s.Name,
+				Selected:            true,
+				Suggested:           true,
+				SelectedFields:      []any{},
+			},
+		})
+	}
+	return c
+}
+
+func (h *Handler) discoverRoutes() {
+	handle(h, "sources/discover_schema", func(req *struct {
+		SourceId     string `json:"sourceId"`
+		DisableCache bool   `json:"disable_cache"`
+	}) (any, *apiError) {
+		s, err := h.source(req.SourceId)
+		if err != nil {
+			return nil, err
+		}
+
+		// A cached catalog is returned as is; discovering again stores a new one.
+		if s.catalogId == "" || req.DisableCache {
+			s.catalogId = newUUID()
+		}
+
+		now := time.Now().Unix()
+		return map[string]any{
+			"catalog":   discoveredCatalog(h.sourceDefinitions[s.SourceDefinitionId].streams),
+			"catalogId": s.catalogId,
+			"jobInfo": map[string]any{
+				"id":         newUUID(),
+				"configType": "discover_schema",
+				"createdAt":  now,
+				"endedAt":    now,
+				"succeeded":  true,
+			},
+		}, nil
+	})
+}
+
+// checkCatalog rejects stream configurations the stream doesn't support.
+func checkCatalog(raw json.RawMessage) *apiError {
+	var c catalog
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return invalidInput(validationError{PropertyPath: "syncCatalog", Message: err.Error()})
+	}
+
+	for i, cs := range c.Streams {
+		if cs.Stream == nil || cs.Config == nil {
+			return invalidInput(required(fmt.Sprintf("syncCatalog.streams[%d]", i)))
+		}
+		path := fmt.Sprintf("syncCatalog.streams[%d].config", i)
+
+		supported := false
+		for _, m := range cs.Stream.SupportedSyncModes {
+			supported = supported || m == cs.Config.SyncMode
+		}
+		if !supported {
+			return invalidInput(validationError{
+				PropertyPath: path + ".syncMode",
+				InvalidValue: cs.Config.SyncMode,
+				Message:      fmt.Sprintf("stream %s only supports %v", cs.Stream.Name, cs.Stream.SupportedSyncModes),
+			})
+		}
+		if err := checkEnum(path+".destinationSyncMode", cs.Config.DestinationSyncMode, "append", "overwrite", "append_dedup"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,239 @@
+package fakeairbyte
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+type connection struct {
+	ConnectionId        string          `json:"connectionId"`
+	Name                string          `json:"name"`
+	NamespaceDefinition string          `json:"namespaceDefinition"`
+	NamespaceFormat     string          `json:"namespaceFormat,omitempty"`
+	Prefix              string          `json:"prefix,omitempty"`
+	SourceId            string          `json:"sourceId"`
+	DestinationId       string          `json:"destinationId"`
+	SyncCatalog         json.RawMessage `json:"syncCatalog"`
+	ScheduleType        string          `json:"scheduleType"`
+	ScheduleData        *scheduleData   `json:"scheduleData,omitempty"`
+	Status              string          `json:"status"`
+	Geography           string          `json:"geography"`
+	BreakingChange      bool            `json:"breakingChange"`
+
+	workspaceId string
+}
+
+type scheduleData struct {
+	BasicSchedule *struct {
+		TimeUnit string `json:"timeUnit"`
+		Units    int64  `json:"units"`
+	} `json:"basicSchedule,omitempty"`
+	Cron *struct {
+		CronExpression string `json:"cronExpression"`
+		CronTimeZone   string `json:"cronTimeZone"`
+	} `json:"cron,omitempty"`
+}
+
+// connectionFields are the fields shared by connections/create and connections/update. Nil means
+// "not sent", which leaves the current value alone on update.
+type connectionFields struct {
+	Name                *string         `json:"name"`
+	NamespaceDefinition *string         `json:"namespaceDefinition"`
+	NamespaceFormat     *string         `json:"namespaceFormat"`
+	Prefix              *string         `json:"prefix"`
+	SyncCatalog         json.RawMessage `json:"syncCatalog"`
+	ScheduleType        *string         `json:"scheduleType"`
+	ScheduleData        *scheduleData   `json:"scheduleData"`
+	Status              *string         `json:"status"`
+	Geography           *string         `json:"geography"`
+}
+
+type connectionIdRequest struct {
+	ConnectionId string `json:"connectionId"`
+}
+
+func (h *Handler) connectionRoutes() {
+	handle(h, "connections/create", func(req *struct {
+		SourceId      string `json:"sourceId"`
+		DestinationId string `json:"destinationId"`
+		connectionFields
+	}) (any, *apiError) {
+		if req.Status == nil {
+			return nil, invalidInput(required("status"))
+		}
+		s, err := h.source(req.SourceId)
+		if err != nil {
+			return nil, err
+		}
+		d, err := h.destination(req.DestinationId)
+		if err != nil {
+			return nil, err
+		}
+		if s.WorkspaceId != d.WorkspaceId {
+			return nil, invalidInput(validationError{
+				PropertyPath: "destinationId",
+				InvalidValue: d.DestinationId,
+				Message:      "must be in the same workspace as the source",
+			})
+		}
+
+		c := &connection{
+			ConnectionId:        newUUID(),
+			Name:                "default",
+			NamespaceDefinition: "source",
+			SourceId:            s.SourceId,
+			DestinationId:       d.DestinationId,
+			SyncCatalog:         json.RawMessage(`{"streams":[]}`),
+			ScheduleType:        "manual",
+			Geography:           h.workspaces[s.WorkspaceId].DefaultGeography,
+			workspaceId:         s.WorkspaceId,
+		}
+		if err := applyConnectionFields(c, req.connectionFields); err != nil {
+			return nil, err
+		}
+		h.connections[c.ConnectionId] = c
+
+		return c, nil
+	})
+
+	handle(h, "connections/get", func(req *connectionIdRequest) (any, *apiError) {
+		return h.connection(req.ConnectionId)
+	})
+
+	handle(h, "connections/list", func(req *struct {
+		WorkspaceId string `json:"workspaceId"`
+	}) (any, *apiError) {
+		if _, err := h.workspace(req.WorkspaceId); err != nil {
+			return nil, err
+		}
+
+		connections := []*connection{}
+		for _, c := range h.connections {
+			if c.workspaceId == req.WorkspaceId && c.Status != "deprecated" {
+				connections = append(connections, c)
+			}
+		}
+		return map[string]any{"connections": connections}, nil
+	})
+
+	handle(h, "connections/update", func(req *struct {
+		ConnectionId string `json:"connectionId"`
+		connectionFields
+	}) (any, *apiError) {
+		c, err := h.connection(req.ConnectionId)
+		if err != nil {
+			return nil, err
+		}
+		if c.Status == "deprecated" {
+			return nil, badRequest(http.StatusBadRequest, "io.airbyte.commons.server.errors.BadObjectSchemaKnownException",
+				"Connection %s is deprecated and can't be updated", c.ConnectionId)
+		}
+		if err := applyConnectionFields(c, req.connectionFields); err != nil {
+			return nil, err
+		}
+		return c, nil
+	})
+
+	// Airbyte doesn't remove deleted connections, it deprecates them.
+	handle(h, "connections/delete", func(req *connectionIdRequest) (any, *apiError) {
+		c, err := h.connection(req.ConnectionId)
+		if err != nil {
+			return nil, err
+		}
+		c.Status = "deprecated"
+		return nil, nil
+	})
+}
+
+func applyConnectionFields(c *connection, f connectionFields) *apiError {
+	if f.Name != nil && strings.TrimSpace(*f.Name) != "" {
+		c.Name = *f.Name
+	}
+	if f.NamespaceDefinition != nil {
+		if err := checkEnum("namespaceDefinition", *f.NamespaceDefinition, "source", "destination", "customformat"); err != nil {
+			return err
+		}
+		c.NamespaceDefinition = *f.NamespaceDefinition
+	}
+	if f.NamespaceFormat != nil {
+		c.NamespaceFormat = *f.NamespaceFormat
+	}
+	if f.Prefix != nil {
+		c.Prefix = *f.Prefix
+	}
+	if len(f.SyncCatalog) > 0 && string(f.SyncCatalog) != "null" {
+		if err := checkCatalog(f.SyncCatalog); err != nil {
+			return err
+		}
+		c.SyncCatalog = f.SyncCatalog
+	}
+	if f.Status != nil {
+		if err := checkEnum("status", *f.Status, "active", "inactive", "deprecated"); err != nil {
+			return err
+		}
+		c.Status = *f.Status
+	}
+	if f.Geography != nil {
+		if err := checkEnum("geography", *f.Geography, "auto", "us", "eu"); err != nil {
+			return err
+		}
+		c.Geography = *f.Geography
+	}
+	if f.ScheduleType != nil {
+		switch *f.ScheduleType {
+		case "manual":
+			c.ScheduleData = nil
+		case "basic":
+			if f.ScheduleData == nil || f.ScheduleData.BasicSchedule == nil {
+				return invalidInput(required("scheduleData.basicSchedule"))
+			}
+			c.ScheduleData = &scheduleData{BasicSchedule: f.ScheduleData.BasicSchedule}
+		case "cron":
+			if f.ScheduleData == nil || f.ScheduleData.Cron == nil || f.ScheduleData.Cron.CronExpression == "" {
+				return invalidInput(required("scheduleData.cron.cronExpression"))
+			}
+			c.ScheduleData = &scheduleData{Cron: f.ScheduleData.Cron}
+		default:
+			return checkEnum("scheduleType", *f.ScheduleType, "manual", "basic", "cron")
+		}
+		c.ScheduleType = *f.ScheduleType
+	}
+
+	return nil
+}
+
+// deprecateConnections deprecates the connections matching a deleted source, destination or workspace.
+func (h *Handler) deprecateConnections(match func(c *connection) bool) {
+	for _, c := range h.connections {
+		if match(c) {
+			c.Status = "deprecated"
+		}
+	}
+}
+
+func (h *Handler) connection(connectionId string) (*connection, *apiError) {
+	if err := checkUUID("connectionId", connectionId); err != nil {
+		return nil, err
+	}
+
+	c, ok := h.connections[connectionId]
+	if !ok {
+		return nil, notFound("STANDARD_SYNC", connectionId)
+	}
+
+	return c, nil
+}
+
+func checkEnum(field string, value string, allowed ...string) *apiError {
+	for _, a := range allowed {
+		if value == a {
+			return nil
+		}
+	}
+	return invalidInput(validationError{
+		PropertyPath: field,
+		InvalidValue: value,
+		Message:      "must be one of " + strings.Join(allowed, ", "),
+	})
+}
@@ -0,0 +1,252 @@
+package fakeairbyte
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+type destinationDefinition struct {
+	DestinationDefinitionId string              `json:"destinationDefinitionId"`
+	Name                    string              `json:"name"`
+	DockerRepository        string              `json:"dockerRepository"`
+	DockerImageTag          string              `json:"dockerImageTag"`
+	DocumentationUrl        string              `json:"documentationUrl"`
+	Icon                    string              `json:"icon,omitempty"`
+	ProtocolVersion         string              `json:"protocolVersion"`
+	ReleaseStage            string              `json:"releaseStage"`
+	ReleaseDate             string              `json:"releaseDate,omitempty"`
+	SupportsDbt             bool                `json:"supportsDbt"`
+	NormalizationConfig     normalizationConfig `json:"normalizationConfig"`
+	ResourceRequirements    json.RawMessage     `json:"resourceRequirements,omitempty"`
+
+	spec        map[string]any
+	workspaceId string
+}
+
+type normalizationConfig struct {
+	Supported                    bool   `json:"supported"`
+	NormalizationRepository      string `json:"normalizationRepository,omitempty"`
+	NormalizationTag             string `json:"normalizationTag,omitempty"`
+	NormalizationIntegrationType string `json:"normalizationIntegrationType,omitempty"`
+}
+
+type destinationDefinitionIdRequest struct {
+	DestinationDefinitionId string `json:"destinationDefinitionId"`
+}
+
+// seedDestinationDefinitions adds the destination definitions a fresh Airbyte ships with (a small
+// subset of them).
+func (h *Handler) seedDestinationDefinitions() {
+	postgres := &destinationDefinition{
+		DestinationDefinitionId: "25c5221d-dce2-4163-ade9-739ef790f503",
+		Name:                    "Postgres",
+		DockerRepository:        "airbyte/destination-postgres",
+		DockerImageTag:          "0.4.0",
+		DocumentationUrl:        "https://docs.airbyte.com/integrations/destinations/postgres",
+		ProtocolVersion:         "0.2.0",
+		ReleaseStage:            "alpha",
+		SupportsDbt:             true,
+		NormalizationConfig: normalizationConfig{
+			Supported:                    true,
+			NormalizationRepository:      "airbyte/normalization",
+			NormalizationTag:             "0.4.3",
+			NormalizationIntegrationType: "postgres",
+		},
+		spec: map[string]any{
+			"type":     "object",
+			"required": []any{"host", "port", "username", "database", "schema"},
+			"properties": map[string]any{
+				"host":     map[string]any{"type": "string"},
+				"port":     map[string]any{"type": "integer", "default": 5432},
+				"database": map[string]any{"type": "string"},
+				"schema":   map[string]any{"type": "string", "default": "public"},
+				"username": map[string]any{"type": "string"},
+				"password": map[string]any{"type": "string", "airbyte_secret": true},
+				"ssl":      map[string]any{"type": "boolean", "default": false},
+				"tunnel_method": map[string]any{
+					"type": "object",
+					"oneOf": []any{
+						map[string]any{
+							"title":    "No Tunnel",
+							"required": []any{"tunnel_method"},
+							"properties": map[string]any{
+								"tunnel_method": map[string]any{"type": "string", "const": "NO_TUNNEL"},
+							},
+						},
+						map[string]any{
+							"title":    "Password Authentication",
+							"required": []any{"tunnel_method", "tunnel_host", "tunnel_port", "tunnel_user", "tunnel_user_password"},
+							"properties": map[string]any{
+								"tunnel_method":        map[string]any{"type": "string", "const": "SSH_PASSWORD_AUTH"},
+								"tunnel_host":          map[string]any{"type": "string"},
+								"tunnel_port":          map[string]any{"type": "integer", "default": 22},
+								"tunnel_user":          map[string]any{"type": "string"},
+								"tunnel_user_password": map[string]any{"type": "string", "airbyte_secret": true},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	localJson := &destinationDefinition{
+		DestinationDefinitionId: "a625d593-bba5-4a1c-a53d-2d246268a816",
+		Name:                    "Local JSON",
+		DockerRepository:        "airbyte/destination-local-json",
+		DockerImageTag:          "0.2.11",
+		DocumentationUrl:        "https://docs.airbyte.com/integrations/destinations/local-json",
+		ProtocolVersion:         "0.2.0",
+		ReleaseStage:            "alpha",
+		spec: map[string]any{
+			"type":     "object",
+			"required": []any{"destination_path"},
+			"properties": map[string]any{
+				"destination_path": map[string]any{"type": "string"},
+			},
+		},
+	}
+
+	h.destinationDefinitions[postgres.DestinationDefinitionId] = postgres
+	h.destinationDefinitions[localJson.DestinationDefinitionId] = localJson
+}
+
+func (h *Handler) destinationDefinitionRoutes() {
+	handle(h, "destination_definitions/create", func(req *newSourceDefinition) (any, *apiError) {
+		return h.createDestinationDefinition(req, "")
+	})
+
+	handle(h, "destination_definitions/create_custom", func(req *struct {
+		WorkspaceId           string               `json:"workspaceId"`
+		DestinationDefinition *newSourceDefinition `json:"destinationDefinition"`
+	}) (any, *apiError) {
+		if _, err := h.workspace(req.WorkspaceId); err != nil {
+			return nil, err
+		}
+		if req.DestinationDefinition == nil {
+			return nil, invalidInput(required("destinationDefinition"))
+		}
+		return h.createDestinationDefinition(req.DestinationDefinition, req.WorkspaceId)
+	})
+
+	handle(h, "destination_definitions/get", func(req *destinationDefinitionIdRequest) (any, *apiError) {
+		return h.destinationDefinition(req.DestinationDefinitionId)
+	})
+
+	handle(h, "destination_definitions/list", func(_ *struct{}) (any, *apiError) {
+		dds := []*destinationDefinition{}
+		for _, dd := range h.destinationDefinitions {
+			if dd.workspaceId == "" {
+				dds = append(dds, dd)
+			}
+		}
+		return map[string]any{"destinationDefinitions": dds}, nil
+	})
+
+	handle(h, "destination_definitions/update", func(req *struct {
+		DestinationDefinitionId string          `json:"destinationDefinitionId"`
+		DockerImageTag          string          `json:"dockerImageTag"`
+		ResourceRequirements    json.RawMessage `json:"resourceRequirements"`
+	}) (any, *apiError) {
+		dd, err := h.destinationDefinition(req.DestinationDefinitionId)
+		if err != nil {
+			return nil, err
+		}
+		if req.DockerImageTag == MissingImageTag {
+			return nil, imagePullError(dd.DockerRepository, req.DockerImageTag)
+		}
+
+		if req.DockerImageTag != "" {
+			dd.DockerImageTag = req.DockerImageTag
+		}
+		if len(req.ResourceRequirements) > 0 && string(req.ResourceRequirements) != "null" {
+			dd.ResourceRequirements = emptyRequirementsAsNil(req.ResourceRequirements)
+		}
+
+		return dd, nil
+	})
+
+	handle(h, "destination_definitions/delete", func(req *destinationDefinitionIdRequest) (any, *apiError) {
+		dd, err := h.destinationDefinition(req.DestinationDefinitionId)
+		if err != nil {
+			return nil, err
+		}
+
+		delete(h.destinationDefinitions, dd.DestinationDefinitionId)
+		for id, d := range h.destinations {
+			if d.DestinationDefinitionId == dd.DestinationDefinitionId {
+				delete(h.destinations, id)
+			}
+		}
+
+		return nil, nil
+	})
+
+	handle(h, "destination_definition_specifications/get", func(req *struct {
+		DestinationDefinitionId string `json:"destinationDefinitionId"`
+		WorkspaceId             string `json:"workspaceId"`
+	}) (any, *apiError) {
+		dd, err := h.destinationDefinition(req.DestinationDefinitionId)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{
+			"destinationDefinitionId":       dd.DestinationDefinitionId,
+			"documentationUrl":              dd.DocumentationUrl,
+			"connectionSpecification":       dd.spec,
+			"supportedDestinationSyncModes": []string{"append", "overwrite"},
+			"supportsDbt":                   dd.SupportsDbt,
+			"supportsNormalization":         dd.NormalizationConfig.Supported,
+		}, nil
+	})
+}
+
+// createDestinationDefinition takes the same body as createSourceDefinition, which is what
+// DestinationDefinitionCreate and SourceDefinitionCreate have in common.
+func (h *Handler) createDestinationDefinition(req *newSourceDefinition, workspaceId string) (any, *apiError) {
+	var missing []validationError
+	if strings.TrimSpace(req.Name) == "" {
+		missing = append(missing, required("name"))
+	}
+	if req.DockerRepository == "" {
+		missing = append(missing, required("dockerRepository"))
+	}
+	if req.DockerImageTag == "" {
+		missing = append(missing, required("dockerImageTag"))
+	}
+	if len(missing) > 0 {
+		return nil, invalidInput(missing...)
+	}
+	if req.DockerImageTag == MissingImageTag {
+		return nil, imagePullError(req.DockerRepository, req.DockerImageTag)
+	}
+
+	dd := &destinationDefinition{
+		DestinationDefinitionId: newUUID(),
+		Name:                    req.Name,
+		DockerRepository:        req.DockerRepository,
+		DockerImageTag:          req.DockerImageTag,
+		DocumentationUrl:        req.DocumentationUrl,
+		Icon:                    req.Icon,
+		ProtocolVersion:         "0.2.0",
+		ReleaseStage:            "custom",
+		ResourceRequirements:    emptyRequirementsAsNil(req.ResourceRequirements),
+		spec:                    customConnectorSpec,
+		workspaceId:             workspaceId,
+	}
+	h.destinationDefinitions[dd.DestinationDefinitionId] = dd
+
+	return dd, nil
+}
+
+func (h *Handler) destinationDefinition(destinationDefinitionId string) (*destinationDefinition, *apiError) {
+	if err := checkUUID("destinationDefinitionId", destinationDefinitionId); err != nil {
+		return nil, err
+	}
+
+	dd, ok := h.destinationDefinitions[destinationDefinitionId]
+	if !ok {
+		return nil, notFound("STANDARD_DESTINATION_DEFINITION", destinationDefinitionId)
+	}
+
+	return dd, nil
+}
@@ -0,0 +1,141 @@
+package fakeairbyte
+
+import (
+	"strings"
+)
+
+type destination struct {
+	DestinationId           string         `json:"destinationId"`
+	DestinationDefinitionId string         `json:"destinationDefinitionId"`
+	WorkspaceId             string         `json:"workspaceId"`
+	Name                    string         `json:"name"`
+	ConnectionConfiguration map[string]any `json:"connectionConfiguration"`
+	DestinationName         string         `json:"destinationName"`
+	Icon                    string         `json:"icon,omitempty"`
+}
+
+type destinationIdRequest struct {
+	DestinationId string `json:"destinationId"`
+}
+
+func (h *Handler) destinationRoutes() {
+	handle(h, "destinations/create", func(req *struct {
+		DestinationDefinitionId string         `json:"destinationDefinitionId"`
+		WorkspaceId             string         `json:"workspaceId"`
+		Name                    string         `json:"name"`
+		ConnectionConfiguration map[string]any `json:"connectionConfiguration"`
+	}) (any, *apiError) {
+		if strings.TrimSpace(req.Name) == "" {
+			return nil, invalidInput(required("name"))
+		}
+		if req.ConnectionConfiguration == nil {
+			return nil, invalidInput(required("connectionConfiguration"))
+		}
+		if _, err := h.workspace(req.WorkspaceId); err != nil {
+			return nil, err
+		}
+		dd, err := h.destinationDefinition(req.DestinationDefinitionId)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkConfiguration(req.ConnectionConfiguration, dd.spec); err != nil {
+			return nil, err
+		}
+
+		d := &destination{
+			DestinationId:           newUUID(),
+			DestinationDefinitionId: dd.DestinationDefinitionId,
+			WorkspaceId:             req.WorkspaceId,
+			Name:                    req.Name,
+			ConnectionConfiguration: req.ConnectionConfiguration,
+			DestinationName:         dd.Name,
+			Icon:                    dd.Icon,
+		}
+		h.destinations[d.DestinationId] = d
+
+		return h.maskedDestination(d), nil
+	})
+
+	handle(h, "destinations/get", func(req *destinationIdRequest) (any, *apiError) {
+		d, err := h.destination(req.DestinationId)
+		if err != nil {
+			return nil, err
+		}
+		return h.maskedDestination(d), nil
+	})
+
+	handle(h, "destinations/list", func(req *struct {
+		WorkspaceId string `json:"workspaceId"`
+	}) (any, *apiError) {
+		if _, err := h.workspace(req.WorkspaceId); err != nil {
+			return nil, err
+		}
+
+		destinations := []*destination{}
+		for _, d := range h.destinations {
+			if d.WorkspaceId == req.WorkspaceId {
+				destinations = append(destinations, h.maskedDestination(d))
+			}
+		}
+		return map[string]any{"destinations": destinations}, nil
+	})
+
+	handle(h, "destinations/update", func(req *struct {
+		DestinationId           string         `json:"destinationId"`
+		Name                    string         `json:"name"`
+		ConnectionConfiguration map[string]any `json:"connectionConfiguration"`
+	}) (any, *apiError) {
+		d, err := h.destination(req.DestinationId)
+		if err != nil {
+			return nil, err
+		}
+		if strings.TrimSpace(req.Name) == "" {
+			return nil, invalidInput(required("name"))
+		}
+		if req.ConnectionConfiguration == nil {
+			return nil, invalidInput(required("connectionConfiguration"))
+		}
+
+		config := mergeSecrets(req.ConnectionConfiguration, d.ConnectionConfiguration)
+		if err := checkConfiguration(config, h.destinationDefinitions[d.DestinationDefinitionId].spec); err != nil {
+			return nil, err
+		}
+
+		d.Name = req.Name
+		d.ConnectionConfiguration = config
+
+		return h.maskedDestination(d), nil
+	})
+
+	handle(h, "destinations/delete", func(req *destinationIdRequest) (any, *apiError) {
+		d, err := h.destination(req.DestinationId)
+		if err != nil {
+			return nil, err
+		}
+
+		delete(h.destinations, d.DestinationId)
+		h.deprecateConnections(func(c *connection) bool { return c.DestinationId == d.DestinationId })
+
+		return nil, nil
+	})
+}
+
+func (h *Handler) destination(destinationId string) (*destination, *apiError) {
+	if err := checkUUID("destinationId", destinationId); err != nil {
+		return nil, err
+	}
+
+	d, ok := h.destinations[destinationId]
+	if !ok {
+		return nil, notFound("DESTINATION_CONNECTION", destinationId)
+	}
+
+	return d, nil
+}
+
+// maskedDestination returns a copy of d as the API shows it, with secrets masked.
+func (h *Handler) maskedDestination(d *destination) *destination {
+	masked := *d
+	masked.ConnectionConfiguration = maskSecrets(d.ConnectionConfiguration, secretFields(h.destinationDefinitions[d.DestinationDefinitionId].spec))
+	return &masked
+}
@@ -0,0 +1,348 @@
+// Package fakeairbyte is an in-memory implementation of the parts of the Airbyte config API used by
+// the provider, for hermetic tests. It mimics the server closely enough to catch real mistakes:
+// slugs, tombstoned workspaces, 404/422 payloads and masked secrets all behave like they do in Airbyte.
+package fakeairbyte
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	DefaultVersion = "0.50.33"
+	maskedSecret   = "**********"
+	apiPrefix      = "/api/v1/"
+)
+
+// Server is a fake Airbyte listening on a local port.
+type Server struct {
+	*httptest.Server
+	*Handler
+}
+
+func NewServer() *Server {
+	h := NewHandler()
+	return &Server{
+		Server:  httptest.NewServer(h),
+		Handler: h,
+	}
+}
+
+// Handler serves the fake API. Its exported fields may be changed between requests.
+type Handler struct {
+	// Version is reported by /deployment/metadata.
+	Version string
+	// Available is reported by /health.
+	Available bool
+
+	mu  sync.Mutex
+	mux *http.ServeMux
+
+	workspaces             map[string]*workspace
+	sourceDefinitions      map[string]*sourceDefinition
+	sources                map[string]*source
+	destinationDefinitions map[string]*destinationDefinition
+	destinations           map[string]*destination
+	connections            map[string]*connection
+}
+
+func NewHandler() *Handler {
+	h := &Handler{
+		Version:                DefaultVersion,
+		Available:              true,
+		mux:                    http.NewServeMux(),
+		workspaces:             make(map[string]*workspace),
+		sourceDefinitions:      make(map[string]*sourceDefinition),
+		sources:                make(map[string]*source),
+		destinationDefinitions: make(map[string]*destinationDefinition),
+		destinations:           make(map[string]*destination),
+		connections:            make(map[string]*connection),
+	}
+
+	h.seed()
+	h.seedDestinationDefinitions()
+	h.routes()
+
+	return h
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+// apiError is the body of every non-2xx response, shaped like Airbyte's KnownExceptionInfo,
+// NotFoundKnownExceptionInfo and InvalidInputExceptionInfo.
+type apiError struct {
+	status                      int
+	Id                          string            `json:"id,omitempty"`
+	Message                     string            `json:"message"`
+	ExceptionClassName          string            `json:"exceptionClassName"`
+	ExceptionStack              []string          `json:"exceptionStack"`
+	RootCauseExceptionClassName string            `json:"rootCauseExceptionClassName,omitempty"`
+	RootCauseExceptionStack     []string          `json:"rootCauseExceptionStack,omitempty"`
+	ValidationErrors            []validationError `json:"validationErrors,omitempty"`
+}
+
+type validationError struct {
+	PropertyPath string `json:"propertyPath"`
+	InvalidValue string `json:"invalidValue"`
+	Message      string `json:"message"`
+}
+
+func notFound(configType string, id string) *apiError {
+	return &apiError{
+		status:                      http.StatusNotFound,
+		Id:                          id,
+		Message:                     fmt.Sprintf("Could not find configuration for %s: %s.", configType, id),
+		ExceptionClassName:          "io.airbyte.server.errors.IdNotFoundKnownException",
+		ExceptionStack:              []string{},
+		RootCauseExceptionClassName: "io.airbyte.config.persistence.ConfigNotFoundException",
+		RootCauseExceptionStack:     []string{},
+	}
+}
+
+func invalidInput(errs ...validationError) *apiError {
+	return &apiError{
+		status:             http.StatusUnprocessableEntity,
+		Message:            "Some properties contained invalid input.",
+		ExceptionClassName: "io.airbyte.server.errors.InvalidInputExceptionMapper",
+		ExceptionStack:     []string{},
+		ValidationErrors:   errs,
+	}
+}
+
+func required(propertyPath string) validationError {
+	return validationError{PropertyPath: propertyPath, Message: "must not be null"}
+}
+
+func badRequest(status int, exceptionClass string, format string, args ...any) *apiError {
+	return &apiError{
+		status:             status,
+		Message:            fmt.Sprintf(format, args...),
+		ExceptionClassName: exceptionClass,
+		ExceptionStack:     []string{},
+	}
+}
+
+// handle registers a POST endpoint that decodes the request body into a new value of type T.
+func handle[T any](h *Handler, endpoint string, fn func(req *T) (any, *apiError)) {
+	h.mux.HandleFunc(apiPrefix+endpoint, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		req := new(T)
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil && err != io.EOF {
+			writeJSON(w, http.StatusBadRequest, badRequest(http.StatusBadRequest,
+				"com.fasterxml.jackson.core.JsonParseException", "Invalid json. %s", err))
+			return
+		}
+
+		h.mu.Lock()
+		resp, apiErr := fn(req)
+		h.mu.Unlock()
+
+		if apiErr != nil {
+			writeJSON(w, apiErr.status, apiErr)
+			return
+		}
+		if resp == nil {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		writeJSON(w, http.StatusOK, resp)
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func (h *Handler) routes() {
+	h.mux.HandleFunc(apiPrefix+"health", func(w http.ResponseWriter, r *http.Request) {
+		h.mu.Lock()
+		available := h.Available
+		h.mu.Unlock()
+
+		writeJSON(w, http.StatusOK, map[string]bool{"available": available})
+	})
+
+	handle(h, "deployment/metadata", func(_ *struct{}) (any, *apiError) {
+		return map[string]string{
+			"id":          "00000000-0000-0000-0000-000000000000",
+			"mode":        "OSS",
+			"version":     h.Version,
+			"environment": "fake",
+		}, nil
+	})
+
+	h.workspaceRoutes()
+	h.sourceDefinitionRoutes()
+	h.sourceRoutes()
+	h.discoverRoutes()
+	h.destinationDefinitionRoutes()
+	h.destinationRoutes()
+	h.connectionRoutes()
+}
+
+func newUUID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// checkUUID mimics the 400 Airbyte returns when an id in the body isn't a UUID.
+func checkUUID(field string, id string) *apiError {
+	if id == "" {
+		return invalidInput(required(field))
+	}
+	if !uuidPattern.MatchString(id) {
+		return badRequest(http.StatusBadRequest, "com.fasterxml.jackson.databind.exc.InvalidFormatException",
+			"Invalid UUID string: %s", id)
+	}
+	return nil
+}
+
+// secretFields returns the names of all properties marked airbyte_secret in a connector spec.
+func secretFields(spec any) map[string]bool {
+	names := make(map[string]bool)
+
+	var walk func(any)
+	walk = func(node any) {
+		switch n := node.(type) {
+		case map[string]any:
+			if props, ok := n["properties"].(map[string]any); ok {
+				for name, prop := range props {
+					if p, ok := prop.(map[string]any); ok && p["airbyte_secret"] == true {
+						names[name] = true
+					}
+				}
+			}
+			for _, child := range n {
+				walk(child)
+			}
+		case []any:
+			for _, child := range n {
+				walk(child)
+			}
+		}
+	}
+	walk(spec)
+
+	return names
+}
+
+// maskSecrets returns a copy of config with every secret value replaced by the Airbyte mask.
+func maskSecrets(config map[string]any, secrets map[string]bool) map[string]any {
+	masked := make(map[string]any, len(config))
+	for k, v := range config {
+		switch val := v.(type) {
+		case map[string]any:
+			masked[k] = maskSecrets(val, secrets)
+		default:
+			if secrets[k] {
+				masked[k] = maskedSecret
+			} else {
+				masked[k] = v
+			}
+		}
+	}
+	return masked
+}
+
+// mergeSecrets keeps the stored value of every secret that an update sends back masked, like
+// Airbyte does when a client round-trips a configuration it read.
+func mergeSecrets(updated map[string]any, stored map[string]any) map[string]any {
+	merged := make(map[string]any, len(updated))
+	for k, v := range updated {
+		switch val := v.(type) {
+		case map[string]any:
+			if old, ok := stored[k].(map[string]any); ok {
+				merged[k] = mergeSecrets(val, old)
+			} else {
+				merged[k] = val
+			}
+		case string:
+			if val == maskedSecret {
+				merged[k] = stored[k]
+			} else {
+				merged[k] = val
+			}
+		default:
+			merged[k] = v
+		}
+	}
+	return merged
+}
+
+// checkConfiguration validates config against the top-level `required` list and property types of
+// the spec, returning the JSON schema error Airbyte returns for a configuration that doesn't fulfill
+// it.
+func checkConfiguration(config map[string]any, spec map[string]any) *apiError {
+	req, _ := spec["required"].([]any)
+	props, _ := spec["properties"].(map[string]any)
+
+	var problems []string
+	for _, r := range req {
+		if name, ok := r.(string); ok {
+			if _, set := config[name]; !set {
+				problems = append(problems, fmt.Sprintf("$.%s: is missing but it is required", name))
+			}
+		}
+	}
+	for name, v := range config {
+		prop, _ := props[name].(map[string]any)
+		expected, ok := prop["type"].(string)
+		if !ok {
+			continue
+		}
+		if found := jsonType(v); found != expected && (expected != "number" || found != "integer") {
+			problems = append(problems, fmt.Sprintf("$.%s: %s found, %s expected", name, found, expected))
+		}
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	sort.Strings(problems)
+
+	return badRequest(http.StatusUnprocessableEntity, "io.airbyte.validation.json.JsonValidationException",
+		"The provided configuration does not fulfill the specification. Errors: json schema validation failed when comparing the data to the json schema. \nErrors: %s",
+		strings.Join(problems, ", "))
+}
+
+// jsonType is the JSON schema type of a decoded JSON value.
+func jsonType(v any) string {
+	switch val := v.(type) {
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case float64:
+		if val == math.Trunc(val) {
+			return "integer"
+		}
+		return "number"
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	default:
+		return "null"
+	}
+}
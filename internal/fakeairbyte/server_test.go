@@ -0,0 +1,207 @@
+package fakeairbyte
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/eabrouwer3/terraform-provider-airbyte/internal/apiclient"
+)
+
+func newClient(t *testing.T) *apiclient.ApiClient {
+	srv := NewServer()
+	t.Cleanup(srv.Close)
+
+	return &apiclient.ApiClient{HostURL: srv.URL, HTTPClient: srv.Client()}
+}
+
+func TestWorkspaceLifecycle(t *testing.T) {
+	c := newClient(t)
+	ctx := context.Background()
+
+	w, err := c.CreateWorkspace(ctx, apiclient.WorkspaceCreate{Name: "Basic Test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w.Slug != "basic-test" {
+		t.Errorf("unexpected slug %q", w.Slug)
+	}
+
+	other, err := c.CreateWorkspace(ctx, apiclient.WorkspaceCreate{Name: "Basic Test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if other.Slug == w.Slug {
+		t.Errorf("expected a unique slug, got %q twice", w.Slug)
+	}
+
+	bySlug, err := c.GetWorkspaceBySlug(ctx, apiclient.SlugRequestBody{Slug: w.Slug})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bySlug.WorkspaceId != w.WorkspaceId {
+		t.Errorf("get_by_slug returned %s, expected %s", bySlug.WorkspaceId, w.WorkspaceId)
+	}
+
+	if err := c.DeleteWorkspace(ctx, apiclient.WorkspaceIdRequestBody{WorkspaceId: w.WorkspaceId}); err != nil {
+		t.Fatal(err)
+	}
+	deleted, err := c.GetWorkspaceById(ctx, w.WorkspaceId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !deleted.IsTombstoned() {
+		t.Errorf("expected deleted workspace to be tombstoned")
+	}
+	if _, err := c.GetWorkspaceBySlug(ctx, apiclient.SlugRequestBody{Slug: w.Slug}); !apiclient.IsNotFound(err) {
+		t.Fatalf("expected deleted workspace to be not found by slug, got %v", err)
+	}
+}
+
+func TestValidationErrors(t *testing.T) {
+	c := newClient(t)
+
+	_, err := c.CreateWorkspace(context.Background(), apiclient.WorkspaceCreate{})
+
+	var apiErr *apiclient.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected an APIError, got %v", err)
+	}
+	if apiErr.StatusCode != http.StatusUnprocessableEntity || len(apiErr.ValidationErrors) != 1 || apiErr.ValidationErrors[0].PropertyPath != "name" {
+		t.Fatalf("unexpected error %+v", apiErr)
+	}
+}
+
+func TestSourceSecretsAreMasked(t *testing.T) {
+	c := newClient(t)
+	ctx := context.Background()
+
+	w, err := c.CreateWorkspace(ctx, apiclient.WorkspaceCreate{Name: "sources"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sd, err := c.CreateSourceDefinition(ctx, apiclient.SourceDefinitionCreate{
+		Name:             "custom",
+		DockerRepository: "example/source-custom",
+		DockerImageTag:   "1.0.0",
+		DocumentationUrl: "https://example.com",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newSource := apiclient.SourceCreate{
+		SourceDefinitionId:      sd.SourceDefinitionId,
+		WorkspaceId:             w.WorkspaceId,
+		Name:                    "custom",
+		ConnectionConfiguration: map[string]any{"start_date": "2020-01-01"},
+	}
+	if _, err := c.CreateSource(ctx, newSource); err == nil || !strings.Contains(err.Error(), "$.api_key") {
+		t.Fatalf("expected a missing api_key error, got %v", err)
+	}
+
+	newSource.ConnectionConfiguration["api_key"] = 1234
+	if _, err := c.CreateSource(ctx, newSource); err == nil || !strings.Contains(err.Error(), "$.api_key: integer found, string expected") {
+		t.Fatalf("expected a type error for api_key, got %v", err)
+	}
+
+	newSource.ConnectionConfiguration["api_key"] = "hunter2"
+	s, err := c.CreateSource(ctx, newSource)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.ConnectionConfiguration["api_key"] != "**********" {
+		t.Errorf("expected api_key to be masked, got %v", s.ConnectionConfiguration["api_key"])
+	}
+
+	// Sending the masked value back keeps the stored secret.
+	_, err = c.UpdateSource(ctx, apiclient.SourceUpdate{
+		SourceId:                s.SourceId,
+		Name:                    "renamed",
+		ConnectionConfiguration: s.ConnectionConfiguration,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSourceDefinitionImagePullFailure(t *testing.T) {
+	c := newClient(t)
+
+	_, err := c.CreateSourceDefinition(context.Background(), apiclient.SourceDefinitionCreate{
+		Name:             "broken",
+		DockerRepository: "example/source-broken",
+		DockerImageTag:   MissingImageTag,
+		DocumentationUrl: "https://example.com",
+	})
+	if apiclient.StatusCode(err) != http.StatusInternalServerError {
+		t.Fatalf("expected a 500, got %v", err)
+	}
+}
+
+func TestDiscoverSchemaAndSyncCatalog(t *testing.T) {
+	c := newClient(t)
+	ctx := context.Background()
+
+	w, err := c.CreateWorkspace(ctx, apiclient.WorkspaceCreate{Name: "catalogs"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := c.CreateSource(ctx, apiclient.SourceCreate{
+		SourceDefinitionId:      "dfd88b22-b603-4c3d-aad7-3701784586b1",
+		WorkspaceId:             w.WorkspaceId,
+		Name:                    "faker",
+		ConnectionConfiguration: map[string]any{"count": 10},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err := c.CreateDestination(ctx, apiclient.DestinationCreate{
+		DestinationDefinitionId: "a625d593-bba5-4a1c-a53d-2d246268a816",
+		WorkspaceId:             w.WorkspaceId,
+		Name:                    "local",
+		ConnectionConfiguration: map[string]any{"destination_path": "/local/catalogs"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	discovered, err := c.DiscoverSourceSchema(ctx, s.SourceId, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(discovered.Catalog.Streams) != 3 || discovered.Catalog.Streams[0].Stream.Name != "users" {
+		t.Fatalf("unexpected catalog %+v", discovered.Catalog)
+	}
+
+	cached, err := c.DiscoverSourceSchema(ctx, s.SourceId, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cached.CatalogId != discovered.CatalogId {
+		t.Errorf("expected the cached catalog %s, got %s", discovered.CatalogId, cached.CatalogId)
+	}
+	fresh, err := c.DiscoverSourceSchema(ctx, s.SourceId, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fresh.CatalogId == discovered.CatalogId {
+		t.Errorf("expected disable_cache to discover a new catalog")
+	}
+
+	// products only supports full refreshes.
+	catalog := discovered.Catalog
+	catalog.Streams[1].Config.SyncMode = apiclient.SyncModeIncremental
+	_, err = c.CreateConnection(ctx, apiclient.ConnectionCreate{
+		SourceId:      s.SourceId,
+		DestinationId: d.DestinationId,
+		Status:        apiclient.ConnectionStatusActive,
+		SyncCatalog:   catalog,
+	})
+	var apiErr *apiclient.APIError
+	if !errors.As(err, &apiErr) || len(apiErr.ValidationErrors) != 1 || apiErr.ValidationErrors[0].PropertyPath != "syncCatalog.streams[1].config.syncMode" {
+		t.Fatalf("expected an unsupported sync mode error, got %v", err)
+	}
+}
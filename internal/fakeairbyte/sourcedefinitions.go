@@ -0,0 +1,282 @@
+package fakeairbyte
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+type sourceDefinition struct {
+	SourceDefinitionId   string          `json:"sourceDefinitionId"`
+	Name                 string          `json:"name"`
+	DockerRepository     string          `json:"dockerRepository"`
+	DockerImageTag       string          `json:"dockerImageTag"`
+	DocumentationUrl     string          `json:"documentationUrl"`
+	Icon                 string          `json:"icon,omitempty"`
+	ProtocolVersion      string          `json:"protocolVersion"`
+	ReleaseStage         string          `json:"releaseStage"`
+	ReleaseDate          string          `json:"releaseDate,omitempty"`
+	SourceType           string          `json:"sourceType,omitempty"`
+	ResourceRequirements json.RawMessage `json:"resourceRequirements,omitempty"`
+
+	spec        map[string]any
+	streams     []*airbyteStream
+	workspaceId string
+}
+
+type sourceDefinitionIdRequest struct {
+	SourceDefinitionId string `json:"sourceDefinitionId"`
+}
+
+type newSourceDefinition struct {
+	Name                 string          `json:"name"`
+	DockerRepository     string          `json:"dockerRepository"`
+	DockerImageTag       string          `json:"dockerImageTag"`
+	DocumentationUrl     string          `json:"documentationUrl"`
+	Icon                 string          `json:"icon"`
+	ResourceRequirements json.RawMessage `json:"resourceRequirements"`
+}
+
+// MissingImageTag is a docker image tag the fake pretends it can't pull, so tests can exercise the
+// 500 Airbyte returns when a connector image doesn't exist.
+const MissingImageTag = "does-not-exist"
+
+// customConnectorSpec is the spec of every definition created through the API. Real Airbyte gets it
+// by running the connector image.
+var customConnectorSpec = map[string]any{
+	"$schema":  "http://json-schema.org/draft-07/schema#",
+	"type":     "object",
+	"required": []any{"api_key"},
+	"properties": map[string]any{
+		"api_key":    map[string]any{"type": "string", "airbyte_secret": true},
+		"start_date": map[string]any{"type": "string", "format": "date"},
+	},
+	"additionalProperties": true,
+}
+
+// seed adds the definitions a fresh Airbyte ships with (a small subset of them).
+func (h *Handler) seed() {
+	faker := &sourceDefinition{
+		SourceDefinitionId: "dfd88b22-b603-4c3d-aad7-3701784586b1",
+		Name:               "Sample Data (Faker)",
+		DockerRepository:   "airbyte/source-faker",
+		DockerImageTag:     "2.0.3",
+		DocumentationUrl:   "https://docs.airbyte.com/integrations/sources/faker",
+		ProtocolVersion:    "0.2.0",
+		ReleaseStage:       "beta",
+		SourceType:         "api",
+		spec: map[string]any{
+			"type":     "object",
+			"required": []any{"count"},
+			"properties": map[string]any{
+				"count": map[string]any{"type": "integer", "default": 1000},
+				"seed":  map[string]any{"type": "integer", "default": -1},
+			},
+		},
+		streams: []*airbyteStream{
+			stream("users", "updated_at", "id", "id", "name", "email", "created_at", "updated_at"),
+			stream("products", "", "id", "id", "make", "model", "price"),
+			stream("purchases", "updated_at", "id", "id", "user_id", "product_id", "purchased_at", "updated_at"),
+		},
+	}
+	github := &sourceDefinition{
+		SourceDefinitionId: "ef69ef6e-aa7f-4af1-a01d-ef775033524e",
+		Name:               "GitHub",
+		DockerRepository:   "airbyte/source-github",
+		DockerImageTag:     "0.3.7",
+		DocumentationUrl:   "https://docs.airbyte.com/integrations/sources/github",
+		ProtocolVersion:    "0.2.0",
+		ReleaseStage:       "generally_available",
+		SourceType:         "api",
+		spec: map[string]any{
+			"type":     "object",
+			"required": []any{"repository", "start_date", "credentials"},
+			"properties": map[string]any{
+				"repository": map[string]any{"type": "string"},
+				"start_date": map[string]any{"type": "string"},
+				"credentials": map[string]any{
+					"type": "object",
+					"oneOf": []any{
+						map[string]any{
+							"title": "Personal Access Token",
+							"properties": map[string]any{
+								"personal_access_token": map[string]any{"type": "string", "airbyte_secret": true},
+							},
+						},
+					},
+				},
+			},
+		},
+		streams: []*airbyteStream{
+			stream("commits", "created_at", "sha", "sha", "repository", "created_at", "message"),
+			stream("issues", "updated_at", "id", "id", "repository", "title", "state", "updated_at"),
+			stream("repositories", "", "id", "id", "full_name", "description"),
+		},
+	}
+
+	h.sourceDefinitions[faker.SourceDefinitionId] = faker
+	h.sourceDefinitions[github.SourceDefinitionId] = github
+}
+
+func (h *Handler) sourceDefinitionRoutes() {
+	handle(h, "source_definitions/create", func(req *newSourceDefinition) (any, *apiError) {
+		return h.createSourceDefinition(req, "")
+	})
+
+	handle(h, "source_definitions/create_custom", func(req *struct {
+		WorkspaceId      string               `json:"workspaceId"`
+		SourceDefinition *newSourceDefinition `json:"sourceDefinition"`
+	}) (any, *apiError) {
+		if _, err := h.workspace(req.WorkspaceId); err != nil {
+			return nil, err
+		}
+		if req.SourceDefinition == nil {
+			return nil, invalidInput(required("sourceDefinition"))
+		}
+		return h.createSourceDefinition(req.SourceDefinition, req.WorkspaceId)
+	})
+
+	handle(h, "source_definitions/get", func(req *sourceDefinitionIdRequest) (any, *apiError) {
+		return h.sourceDefinition(req.SourceDefinitionId)
+	})
+
+	handle(h, "source_definitions/list", func(_ *struct{}) (any, *apiError) {
+		sds := []*sourceDefinition{}
+		for _, sd := range h.sourceDefinitions {
+			if sd.workspaceId == "" {
+				sds = append(sds, sd)
+			}
+		}
+		return map[string]any{"sourceDefinitions": sds}, nil
+	})
+
+	handle(h, "source_definitions/update", func(req *struct {
+		SourceDefinitionId   string          `json:"sourceDefinitionId"`
+		DockerImageTag       string          `json:"dockerImageTag"`
+		ResourceRequirements json.RawMessage `json:"resourceRequirements"`
+	}) (any, *apiError) {
+		sd, err := h.sourceDefinition(req.SourceDefinitionId)
+		if err != nil {
+			return nil, err
+		}
+		if req.DockerImageTag == MissingImageTag {
+			return nil, imagePullError(sd.DockerRepository, req.DockerImageTag)
+		}
+
+		if req.DockerImageTag != "" {
+			sd.DockerImageTag = req.DockerImageTag
+		}
+		if len(req.ResourceRequirements) > 0 && string(req.ResourceRequirements) != "null" {
+			sd.ResourceRequirements = emptyRequirementsAsNil(req.ResourceRequirements)
+		}
+
+		return sd, nil
+	})
+
+	handle(h, "source_definitions/delete", func(req *sourceDefinitionIdRequest) (any, *apiError) {
+		sd, err := h.sourceDefinition(req.SourceDefinitionId)
+		if err != nil {
+			return nil, err
+		}
+
+		delete(h.sourceDefinitions, sd.SourceDefinitionId)
+		for id, s := range h.sources {
+			if s.SourceDefinitionId == sd.SourceDefinitionId {
+				delete(h.sources, id)
+			}
+		}
+
+		return nil, nil
+	})
+
+	handle(h, "source_definition_specifications/get", func(req *struct {
+		SourceDefinitionId string `json:"sourceDefinitionId"`
+		WorkspaceId        string `json:"workspaceId"`
+	}) (any, *apiError) {
+		sd, err := h.sourceDefinition(req.SourceDefinitionId)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{
+			"sourceDefinitionId":      sd.SourceDefinitionId,
+			"documentationUrl":        sd.DocumentationUrl,
+			"connectionSpecification": sd.spec,
+		}, nil
+	})
+}
+
+func (h *Handler) createSourceDefinition(req *newSourceDefinition, workspaceId string) (any, *apiError) {
+	var missing []validationError
+	if strings.TrimSpace(req.Name) == "" {
+		missing = append(missing, required("name"))
+	}
+	if req.DockerRepository == "" {
+		missing = append(missing, required("dockerRepository"))
+	}
+	if req.DockerImageTag == "" {
+		missing = append(missing, required("dockerImageTag"))
+	}
+	if len(missing) > 0 {
+		return nil, invalidInput(missing...)
+	}
+	if req.DockerImageTag == MissingImageTag {
+		return nil, imagePullError(req.DockerRepository, req.DockerImageTag)
+	}
+
+	sd := &sourceDefinition{
+		SourceDefinitionId:   newUUID(),
+		Name:                 req.Name,
+		DockerRepository:     req.DockerRepository,
+		DockerImageTag:       req.DockerImageTag,
+		DocumentationUrl:     req.DocumentationUrl,
+		Icon:                 req.Icon,
+		ProtocolVersion:      "0.2.0",
+		ReleaseStage:         "custom",
+		ResourceRequirements: emptyRequirementsAsNil(req.ResourceRequirements),
+		spec:                 customConnectorSpec,
+		streams:              customConnectorStreams,
+		workspaceId:          workspaceId,
+	}
+	h.sourceDefinitions[sd.SourceDefinitionId] = sd
+
+	return sd, nil
+}
+
+func (h *Handler) sourceDefinition(sourceDefinitionId string) (*sourceDefinition, *apiError) {
+	if err := checkUUID("sourceDefinitionId", sourceDefinitionId); err != nil {
+		return nil, err
+	}
+
+	sd, ok := h.sourceDefinitions[sourceDefinitionId]
+	if !ok {
+		return nil, notFound("STANDARD_SOURCE_DEFINITION", sourceDefinitionId)
+	}
+
+	return sd, nil
+}
+
+// imagePullError is the 500 Airbyte answers with when it can't run the connector image to get its spec.
+func imagePullError(repository string, tag string) *apiError {
+	return badRequest(http.StatusInternalServerError, "io.airbyte.commons.server.errors.InternalServerKnownException",
+		"Get Spec job failed: unable to pull image %s:%s", repository, tag)
+}
+
+// emptyRequirementsAsNil drops resource requirements that set nothing, like Airbyte does when reading them back.
+func emptyRequirementsAsNil(raw json.RawMessage) json.RawMessage {
+	var reqs map[string]any
+	if err := json.Unmarshal(raw, &reqs); err != nil || len(reqs) == 0 {
+		return nil
+	}
+	if jobSpecific, ok := reqs["jobSpecific"].([]any); ok && len(jobSpecific) == 0 {
+		delete(reqs, "jobSpecific")
+	}
+	if def, ok := reqs["default"].(map[string]any); ok && len(def) == 0 {
+		delete(reqs, "default")
+	}
+	if len(reqs) == 0 {
+		return nil
+	}
+
+	cleaned, _ := json.Marshal(reqs)
+	return cleaned
+}
@@ -0,0 +1,143 @@
+package fakeairbyte
+
+import (
+	"strings"
+)
+
+type source struct {
+	SourceId                string         `json:"sourceId"`
+	SourceDefinitionId      string         `json:"sourceDefinitionId"`
+	WorkspaceId             string         `json:"workspaceId"`
+	Name                    string         `json:"name"`
+	ConnectionConfiguration map[string]any `json:"connectionConfiguration"`
+	SourceName              string         `json:"sourceName"`
+	Icon                    string         `json:"icon,omitempty"`
+
+	catalogId string
+}
+
+type sourceIdRequest struct {
+	SourceId string `json:"sourceId"`
+}
+
+func (h *Handler) sourceRoutes() {
+	handle(h, "sources/create", func(req *struct {
+		SourceDefinitionId      string         `json:"sourceDefinitionId"`
+		WorkspaceId             string         `json:"workspaceId"`
+		Name                    string         `json:"name"`
+		ConnectionConfiguration map[string]any `json:"connectionConfiguration"`
+	}) (any, *apiError) {
+		if strings.TrimSpace(req.Name) == "" {
+			return nil, invalidInput(required("name"))
+		}
+		if req.ConnectionConfiguration == nil {
+			return nil, invalidInput(required("connectionConfiguration"))
+		}
+		if _, err := h.workspace(req.WorkspaceId); err != nil {
+			return nil, err
+		}
+		sd, err := h.sourceDefinition(req.SourceDefinitionId)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkConfiguration(req.ConnectionConfiguration, sd.spec); err != nil {
+			return nil, err
+		}
+
+		s := &source{
+			SourceId:                newUUID(),
+			SourceDefinitionId:      sd.SourceDefinitionId,
+			WorkspaceId:             req.WorkspaceId,
+			Name:                    req.Name,
+			ConnectionConfiguration: req.ConnectionConfiguration,
+			SourceName:              sd.Name,
+			Icon:                    sd.Icon,
+		}
+		h.sources[s.SourceId] = s
+
+		return h.maskedSource(s), nil
+	})
+
+	handle(h, "sources/get", func(req *sourceIdRequest) (any, *apiError) {
+		s, err := h.source(req.SourceId)
+		if err != nil {
+			return nil, err
+		}
+		return h.maskedSource(s), nil
+	})
+
+	handle(h, "sources/list", func(req *struct {
+		WorkspaceId string `json:"workspaceId"`
+	}) (any, *apiError) {
+		if _, err := h.workspace(req.WorkspaceId); err != nil {
+			return nil, err
+		}
+
+		sources := []*source{}
+		for _, s := range h.sources {
+			if s.WorkspaceId == req.WorkspaceId {
+				sources = append(sources, h.maskedSource(s))
+			}
+		}
+		return map[string]any{"sources": sources}, nil
+	})
+
+	handle(h, "sources/update", func(req *struct {
+		SourceId                string         `json:"sourceId"`
+		Name                    string         `json:"name"`
+		ConnectionConfiguration map[string]any `json:"connectionConfiguration"`
+	}) (any, *apiError) {
+		s, err := h.source(req.SourceId)
+		if err != nil {
+			return nil, err
+		}
+		if strings.TrimSpace(req.Name) == "" {
+			return nil, invalidInput(required("name"))
+		}
+		if req.ConnectionConfiguration == nil {
+			return nil, invalidInput(required("connectionConfiguration"))
+		}
+
+		config := mergeSecrets(req.ConnectionConfiguration, s.ConnectionConfiguration)
+		if err := checkConfiguration(config, h.sourceDefinitions[s.SourceDefinitionId].spec); err != nil {
+			return nil, err
+		}
+
+		s.Name = req.Name
+		s.ConnectionConfiguration = config
+
+		return h.maskedSource(s), nil
+	})
+
+	handle(h, "sources/delete", func(req *sourceIdRequest) (any, *apiError) {
+		s, err := h.source(req.SourceId)
+		if err != nil {
+			return nil, err
+		}
+
+		delete(h.sources, s.SourceId)
+		h.deprecateConnections(func(c *connection) bool { return c.SourceId == s.SourceId })
+
+		return nil, nil
+	})
+}
+
+func (h *Handler) source(sourceId string) (*source, *apiError) {
+	if err := checkUUID("sourceId", sourceId); err != nil {
+		return nil, err
+	}
+
+	s, ok := h.sources[sourceId]
+	if !ok {
+		return nil, notFound("SOURCE_CONNECTION", sourceId)
+	}
+
+	return s, nil
+}
+
+// maskedSource returns a copy of s as the API shows it, with secrets masked.
+func (h *Handler) maskedSource(s *source) *source {
+	masked := *s
+	masked.ConnectionConfiguration = maskSecrets(s.ConnectionConfiguration, secretFields(h.sourceDefinitions[s.SourceDefinitionId].spec))
+	return &masked
+}
@@ -0,0 +1,213 @@
+package fakeairbyte
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+type workspace struct {
+	WorkspaceId             string          `json:"workspaceId"`
+	CustomerId              string          `json:"customerId"`
+	Email                   string          `json:"email,omitempty"`
+	Name                    string          `json:"name"`
+	Slug                    string          `json:"slug"`
+	InitialSetupComplete    bool            `json:"initialSetupComplete"`
+	DisplaySetupWizard      bool            `json:"displaySetupWizard"`
+	AnonymousDataCollection bool            `json:"anonymousDataCollection"`
+	News                    bool            `json:"news"`
+	SecurityUpdates         bool            `json:"securityUpdates"`
+	Notifications           json.RawMessage `json:"notifications"`
+	FirstCompletedSync      bool            `json:"firstCompletedSync"`
+	FeedbackDone            bool            `json:"feedbackDone"`
+	DefaultGeography        string          `json:"defaultGeography"`
+	Tombstone               bool            `json:"tombstone"`
+}
+
+// workspaceFields are the optional fields shared by workspaces/create and workspaces/update. Nil
+// means "not sent", which leaves the current value alone on update.
+type workspaceFields struct {
+	Email                   *string         `json:"email"`
+	AnonymousDataCollection *bool           `json:"anonymousDataCollection"`
+	News                    *bool           `json:"news"`
+	SecurityUpdates         *bool           `json:"securityUpdates"`
+	DisplaySetupWizard      *bool           `json:"displaySetupWizard"`
+	InitialSetupComplete    *bool           `json:"initialSetupComplete"`
+	Notifications           json.RawMessage `json:"notifications"`
+	DefaultGeography        *string         `json:"defaultGeography"`
+}
+
+type workspaceIdRequest struct {
+	WorkspaceId string `json:"workspaceId"`
+}
+
+var slugSeparators = regexp.MustCompile(`[^a-z0-9_]+`)
+
+func (h *Handler) workspaceRoutes() {
+	handle(h, "workspaces/create", func(req *struct {
+		Name string `json:"name"`
+		workspaceFields
+	}) (any, *apiError) {
+		if strings.TrimSpace(req.Name) == "" {
+			return nil, invalidInput(required("name"))
+		}
+
+		w := &workspace{
+			WorkspaceId:      newUUID(),
+			CustomerId:       newUUID(),
+			Name:             req.Name,
+			Slug:             h.uniqueSlug(req.Name),
+			Notifications:    json.RawMessage("[]"),
+			DefaultGeography: "auto",
+			SecurityUpdates:  true,
+			News:             true,
+		}
+		if err := applyWorkspaceFields(w, req.workspaceFields); err != nil {
+			return nil, err
+		}
+		h.workspaces[w.WorkspaceId] = w
+
+		return w, nil
+	})
+
+	// Airbyte still returns soft-deleted workspaces by id, flagged with tombstone.
+	handle(h, "workspaces/get", func(req *workspaceIdRequest) (any, *apiError) {
+		if err := checkUUID("workspaceId", req.WorkspaceId); err != nil {
+			return nil, err
+		}
+		if w, ok := h.workspaces[req.WorkspaceId]; ok {
+			return w, nil
+		}
+		return nil, notFound("STANDARD_WORKSPACE", req.WorkspaceId)
+	})
+
+	handle(h, "workspaces/get_by_slug", func(req *struct {
+		Slug string `json:"slug"`
+	}) (any, *apiError) {
+		for _, w := range h.workspaces {
+			if w.Slug == req.Slug && !w.Tombstone {
+				return w, nil
+			}
+		}
+		return nil, notFound("STANDARD_WORKSPACE", req.Slug)
+	})
+
+	handle(h, "workspaces/list", func(_ *struct{}) (any, *apiError) {
+		workspaces := []*workspace{}
+		for _, w := range h.workspaces {
+			if !w.Tombstone {
+				workspaces = append(workspaces, w)
+			}
+		}
+		return map[string]any{"workspaces": workspaces}, nil
+	})
+
+	handle(h, "workspaces/update", func(req *struct {
+		WorkspaceId string `json:"workspaceId"`
+		workspaceFields
+	}) (any, *apiError) {
+		w, apiErr := h.workspace(req.WorkspaceId)
+		if apiErr != nil {
+			return nil, apiErr
+		}
+		if err := applyWorkspaceFields(w, req.workspaceFields); err != nil {
+			return nil, err
+		}
+		return w, nil
+	})
+
+	handle(h, "workspaces/delete", func(req *workspaceIdRequest) (any, *apiError) {
+		w, apiErr := h.workspace(req.WorkspaceId)
+		if apiErr != nil {
+			return nil, apiErr
+		}
+
+		// Airbyte soft-deletes workspaces, together with everything in them.
+		w.Tombstone = true
+		for id, s := range h.sources {
+			if s.WorkspaceId == w.WorkspaceId {
+				delete(h.sources, id)
+			}
+		}
+		for id, d := range h.destinations {
+			if d.WorkspaceId == w.WorkspaceId {
+				delete(h.destinations, id)
+			}
+		}
+		h.deprecateConnections(func(c *connection) bool { return c.workspaceId == w.WorkspaceId })
+
+		return nil, nil
+	})
+}
+
+func (h *Handler) workspace(workspaceId string) (*workspace, *apiError) {
+	if err := checkUUID("workspaceId", workspaceId); err != nil {
+		return nil, err
+	}
+
+	w, ok := h.workspaces[workspaceId]
+	if !ok || w.Tombstone {
+		return nil, notFound("STANDARD_WORKSPACE", workspaceId)
+	}
+
+	return w, nil
+}
+
+// uniqueSlug derives a slug from the name the way Airbyte does, adding a suffix on collisions.
+func (h *Handler) uniqueSlug(name string) string {
+	base := strings.Trim(slugSeparators.ReplaceAllString(strings.ToLower(name), "-"), "-")
+
+	slug := base
+	for i := 1; ; i++ {
+		taken := false
+		for _, w := range h.workspaces {
+			if w.Slug == slug {
+				taken = true
+				break
+			}
+		}
+		if !taken {
+			return slug
+		}
+		slug = fmt.Sprintf("%s-%d", base, i)
+	}
+}
+
+func applyWorkspaceFields(w *workspace, f workspaceFields) *apiError {
+	if f.Email != nil {
+		w.Email = *f.Email
+	}
+	if f.AnonymousDataCollection != nil {
+		w.AnonymousDataCollection = *f.AnonymousDataCollection
+	}
+	if f.News != nil {
+		w.News = *f.News
+	}
+	if f.SecurityUpdates != nil {
+		w.SecurityUpdates = *f.SecurityUpdates
+	}
+	if f.DisplaySetupWizard != nil {
+		w.DisplaySetupWizard = *f.DisplaySetupWizard
+	}
+	if f.InitialSetupComplete != nil {
+		w.InitialSetupComplete = *f.InitialSetupComplete
+	}
+	if f.DefaultGeography != nil {
+		switch *f.DefaultGeography {
+		case "auto", "us", "eu":
+			w.DefaultGeography = *f.DefaultGeography
+		default:
+			return invalidInput(validationError{
+				PropertyPath: "defaultGeography",
+				InvalidValue: *f.DefaultGeography,
+				Message:      "must be one of auto, us, eu",
+			})
+		}
+	}
+	if len(f.Notifications) > 0 && string(f.Notifications) != "null" {
+		w.Notifications = f.Notifications
+	}
+
+	return nil
+}
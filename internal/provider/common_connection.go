@@ -0,0 +1,393 @@
+package provider
+
+import (
+	"fmt"
+	"github.com/eabrouwer3/terraform-provider-airbyte/internal/apiclient"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"strings"
+)
+
+func FlattenConnection(d *schema.ResourceData, c *apiclient.ConnectionRead) error {
+	if err := d.Set("name", c.Name); err != nil {
+		return err
+	}
+	if err := d.Set("source_id", c.SourceId); err != nil {
+		return err
+	}
+	if err := d.Set("destination_id", c.DestinationId); err != nil {
+		return err
+	}
+	if err := d.Set("namespace_definition", c.NamespaceDefinition); err != nil {
+		return err
+	}
+	if err := d.Set("namespace_format", c.NamespaceFormat); err != nil {
+		return err
+	}
+	if err := d.Set("prefix", c.Prefix); err != nil {
+		return err
+	}
+	if err := d.Set("status", c.Status); err != nil {
+		return err
+	}
+	if err := d.Set("geography", c.Geography); err != nil {
+		return err
+	}
+	if err := d.Set("schedule", flattenConnectionSchedule(d, c)); err != nil {
+		return err
+	}
+	if err := d.Set("stream", flattenConnectionStreams(d, c)); err != nil {
+		return err
+	}
+	if err := d.Set("effective_streams", flattenEffectiveStreams(&c.SyncCatalog)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func flattenConnectionSchedule(d *schema.ResourceData, c *apiclient.ConnectionRead) []interface{} {
+	schedule := map[string]interface{}{
+		"schedule_type": string(c.ScheduleType),
+	}
+
+	switch {
+	case c.ScheduleType == apiclient.ConnectionScheduleTypeBasic && c.ScheduleData != nil && c.ScheduleData.BasicSchedule != nil:
+		schedule["units"] = int(c.ScheduleData.BasicSchedule.Units)
+		schedule["time_unit"] = c.ScheduleData.BasicSchedule.TimeUnit
+	case c.ScheduleType == apiclient.ConnectionScheduleTypeCron && c.ScheduleData != nil && c.ScheduleData.Cron != nil:
+		schedule["cron_expression"] = c.ScheduleData.Cron.CronExpression
+		schedule["cron_time_zone"] = c.ScheduleData.Cron.CronTimeZone
+	default:
+		// Manual is the same as no schedule at all, so only keep the block if it was configured.
+		if schedules := d.Get("schedule").([]interface{}); len(schedules) == 0 {
+			return []interface{}{}
+		}
+		schedule["schedule_type"] = string(apiclient.ConnectionScheduleTypeManual)
+	}
+
+	return []interface{}{schedule}
+}
+
+// streamKey identifies a stream within a catalog.
+type streamKey struct {
+	Namespace string
+	Name      string
+}
+
+func (k streamKey) String() string {
+	if k.Namespace == "" {
+		return k.Name
+	}
+	return k.Namespace + "." + k.Name
+}
+
+func streamBlockKey(s map[string]interface{}) streamKey {
+	return streamKey{Namespace: s["namespace"].(string), Name: s["name"].(string)}
+}
+
+// flattenConnectionStreams refreshes the stream blocks already in state from the connection's
+// catalog. Only the attributes that were configured are read back, since the rest follow the
+// discovered catalog. Streams that are gone from the catalog are dropped.
+func flattenConnectionStreams(d *schema.ResourceData, c *apiclient.ConnectionRead) []interface{} {
+	configs := map[streamKey]*apiclient.AirbyteStreamConfiguration{}
+	for _, cs := range c.SyncCatalog.Streams {
+		if cs.Stream != nil && cs.Config != nil {
+			configs[streamKey{Namespace: cs.Stream.Namespace, Name: cs.Stream.Name}] = cs.Config
+		}
+	}
+
+	streams := []interface{}{}
+	for _, raw := range d.Get("stream").(*schema.Set).List() {
+		prior := raw.(map[string]interface{})
+		config, ok := configs[streamBlockKey(prior)]
+		if !ok {
+			continue
+		}
+
+		s := map[string]interface{}{}
+		for k, v := range prior {
+			s[k] = v
+		}
+		s["selected"] = config.Selected == nil || *config.Selected
+		if prior["sync_mode"].(string) != "" {
+			s["sync_mode"] = string(config.SyncMode)
+		}
+		if prior["destination_sync_mode"].(string) != "" {
+			s["destination_sync_mode"] = string(config.DestinationSyncMode)
+		}
+		if len(prior["cursor_field"].([]interface{})) > 0 {
+			s["cursor_field"] = flattenStringList(config.CursorField)
+		}
+		if len(prior["primary_key"].([]interface{})) > 0 {
+			primaryKey := []interface{}{}
+			for _, path := range config.PrimaryKey {
+				primaryKey = append(primaryKey, flattenStringList(path))
+			}
+			s["primary_key"] = primaryKey
+		}
+		if len(prior["selected_fields"].([]interface{})) > 0 {
+			fields := []interface{}{}
+			if config.FieldSelectionEnabled != nil && *config.FieldSelectionEnabled {
+				for _, f := range config.SelectedFields {
+					fields = append(fields, strings.Join(f.FieldPath, "."))
+				}
+			}
+			s["selected_fields"] = fields
+		}
+
+		streams = append(streams, s)
+	}
+
+	return streams
+}
+
+// flattenEffectiveStreams lists the configuration of every stream of a catalog.
+func flattenEffectiveStreams(catalog *apiclient.AirbyteCatalog) []interface{} {
+	streams := []interface{}{}
+	if catalog == nil {
+		return streams
+	}
+
+	for _, cs := range catalog.Streams {
+		if cs.Stream == nil || cs.Config == nil {
+			continue
+		}
+
+		primaryKey := []interface{}{}
+		for _, path := range cs.Config.PrimaryKey {
+			primaryKey = append(primaryKey, flattenStringList(path))
+		}
+		fields := []interface{}{}
+		if cs.Config.FieldSelectionEnabled != nil && *cs.Config.FieldSelectionEnabled {
+			for _, f := range cs.Config.SelectedFields {
+				fields = append(fields, strings.Join(f.FieldPath, "."))
+			}
+		}
+
+		streams = append(streams, map[string]interface{}{
+			"name":                  cs.Stream.Name,
+			"namespace":             cs.Stream.Namespace,
+			"selected":              cs.Config.Selected == nil || *cs.Config.Selected,
+			"sync_mode":             string(cs.Config.SyncMode),
+			"destination_sync_mode": string(cs.Config.DestinationSyncMode),
+			"cursor_field":          flattenStringList(cs.Config.CursorField),
+			"primary_key":           primaryKey,
+			"selected_fields":       fields,
+		})
+	}
+
+	return streams
+}
+
+// mergeSyncCatalog configures the discovered catalog of the source: streams with a stream block
+// take their settings from it, the others follow stream_defaults. Attributes left unset keep the
+// configuration Airbyte suggested when discovering the stream.
+func mergeSyncCatalog(discovered *apiclient.AirbyteCatalog, streams []interface{}, defaults []interface{}) (*apiclient.AirbyteCatalog, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	configured := map[streamKey]map[string]interface{}{}
+	for _, raw := range streams {
+		s := raw.(map[string]interface{})
+		configured[streamBlockKey(s)] = s
+	}
+
+	def := map[string]interface{}{"selected": false, "sync_mode": "", "destination_sync_mode": ""}
+	if len(defaults) > 0 && defaults[0] != nil {
+		def = defaults[0].(map[string]interface{})
+	}
+
+	merged := &apiclient.AirbyteCatalog{Streams: []apiclient.AirbyteStreamAndConfiguration{}}
+	found := map[streamKey]bool{}
+	for _, cs := range discovered.Streams {
+		if cs.Stream == nil {
+			continue
+		}
+		k := streamKey{Namespace: cs.Stream.Namespace, Name: cs.Stream.Name}
+		config := applyStreamDefaults(cs.Stream, discoveredStreamConfig(cs), def)
+
+		if s, ok := configured[k]; ok {
+			found[k] = true
+			config = applyStreamBlock(config, s)
+		}
+		if *config.Selected {
+			diags = append(diags, checkStreamConfig(k, cs.Stream, config)...)
+		}
+
+		merged.Streams = append(merged.Streams, apiclient.AirbyteStreamAndConfiguration{Stream: cs.Stream, Config: config})
+	}
+
+	for _, raw := range streams {
+		if k := streamBlockKey(raw.(map[string]interface{})); !found[k] {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       fmt.Sprintf("Stream %s not found", k),
+				Detail:        fmt.Sprintf("The source doesn't expose a stream %s. Available streams: %s.", k, strings.Join(catalogStreamNames(discovered), ", ")),
+				AttributePath: cty.GetAttrPath("stream"),
+			})
+		}
+	}
+
+	return merged, diags
+}
+
+// discoveredStreamConfig copies the configuration suggested by discovery, or builds one for servers
+// that don't suggest any.
+func discoveredStreamConfig(cs apiclient.AirbyteStreamAndConfiguration) *apiclient.AirbyteStreamConfiguration {
+	if cs.Config != nil {
+		config := *cs.Config
+		return &config
+	}
+
+	config := &apiclient.AirbyteStreamConfiguration{
+		SyncMode:            apiclient.SyncModeFullRefresh,
+		DestinationSyncMode: apiclient.DestinationSyncModeAppend,
+		CursorField:         cs.Stream.DefaultCursorField,
+		PrimaryKey:          cs.Stream.SourceDefinedPrimaryKey,
+		AliasName:           cs.Stream.Name,
+	}
+	if len(cs.Stream.SupportedSyncModes) > 0 && !supportsSyncMode(cs.Stream, config.SyncMode) {
+		config.SyncMode = cs.Stream.SupportedSyncModes[0]
+	}
+	return config
+}
+
+// applyStreamDefaults selects the stream according to stream_defaults. The default sync modes are
+// only applied to streams that can use them, the others keep their discovered ones.
+func applyStreamDefaults(stream *apiclient.AirbyteStream, config *apiclient.AirbyteStreamConfiguration, def map[string]interface{}) *apiclient.AirbyteStreamConfiguration {
+	selected := def["selected"].(bool)
+	config.Selected = &selected
+
+	candidate := *config
+	if v := def["sync_mode"].(string); v != "" {
+		candidate.SyncMode = apiclient.SyncMode(v)
+	}
+	if v := def["destination_sync_mode"].(string); v != "" {
+		candidate.DestinationSyncMode = apiclient.DestinationSyncMode(v)
+	}
+	if len(checkStreamConfig(streamKey{}, stream, &candidate)) == 0 {
+		return &candidate
+	}
+	return config
+}
+
+func applyStreamBlock(config *apiclient.AirbyteStreamConfiguration, s map[string]interface{}) *apiclient.AirbyteStreamConfiguration {
+	selected := s["selected"].(bool)
+	config.Selected = &selected
+
+	if v := s["sync_mode"].(string); v != "" {
+		config.SyncMode = apiclient.SyncMode(v)
+	}
+	if v := s["destination_sync_mode"].(string); v != "" {
+		config.DestinationSyncMode = apiclient.DestinationSyncMode(v)
+	}
+	if v := s["cursor_field"].([]interface{}); len(v) > 0 {
+		config.CursorField = expandStringList(v)
+	}
+	if v := s["primary_key"].([]interface{}); len(v) > 0 {
+		config.PrimaryKey = [][]string{}
+		for _, path := range v {
+			config.PrimaryKey = append(config.PrimaryKey, expandStringList(path.([]interface{})))
+		}
+	}
+
+	fields := s["selected_fields"].([]interface{})
+	fieldSelection := len(fields) > 0
+	config.FieldSelectionEnabled = &fieldSelection
+	config.SelectedFields = []apiclient.SelectedFieldInfo{}
+	for _, f := range fields {
+		config.SelectedFields = append(config.SelectedFields, apiclient.SelectedFieldInfo{FieldPath: []string{f.(string)}})
+	}
+
+	return config
+}
+
+// checkStreamConfig reports the stream configurations Airbyte can't sync.
+func checkStreamConfig(k streamKey, stream *apiclient.AirbyteStream, config *apiclient.AirbyteStreamConfiguration) diag.Diagnostics {
+	var diags diag.Diagnostics
+	invalid := func(summary string, detail string) {
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       fmt.Sprintf("Invalid configuration for stream %s: %s", k, summary),
+			Detail:        detail,
+			AttributePath: cty.GetAttrPath("stream"),
+		})
+	}
+
+	sourceDefinedCursor := stream.SourceDefinedCursor != nil && *stream.SourceDefinedCursor
+	if !supportsSyncMode(stream, config.SyncMode) {
+		modes := []string{}
+		for _, m := range stream.SupportedSyncModes {
+			modes = append(modes, string(m))
+		}
+		invalid(fmt.Sprintf("sync_mode %s isn't supported", config.SyncMode), fmt.Sprintf("The stream supports: %s.", strings.Join(modes, ", ")))
+	} else if config.SyncMode == apiclient.SyncModeIncremental && !sourceDefinedCursor && len(config.CursorField) == 0 {
+		invalid("incremental syncs need a cursor_field", "The source doesn't define a cursor for this stream.")
+	}
+	if config.DestinationSyncMode == apiclient.DestinationSyncModeAppendDedup && len(config.PrimaryKey) == 0 {
+		invalid("append_dedup needs a primary_key", "The source doesn't define a primary key for this stream.")
+	}
+
+	if config.FieldSelectionEnabled == nil || !*config.FieldSelectionEnabled {
+		return diags
+	}
+	properties, _ := stream.JsonSchema["properties"].(map[string]any)
+	selected := map[string]bool{}
+	for _, f := range config.SelectedFields {
+		name := strings.Join(f.FieldPath, ".")
+		selected[name] = true
+		if _, ok := properties[name]; !ok {
+			invalid(fmt.Sprintf("unknown field %s in selected_fields", name), "Only the top-level fields of the stream's JSON schema can be selected.")
+		}
+	}
+	required := [][]string{}
+	if config.SyncMode == apiclient.SyncModeIncremental {
+		required = append(required, config.CursorField)
+	}
+	if config.DestinationSyncMode == apiclient.DestinationSyncModeAppendDedup {
+		required = append(required, config.PrimaryKey...)
+	}
+	for _, path := range required {
+		if len(path) > 0 && !selected[path[0]] {
+			invalid(fmt.Sprintf("selected_fields must include %s", path[0]), "The cursor and primary key fields are always synced.")
+		}
+	}
+
+	return diags
+}
+
+func supportsSyncMode(stream *apiclient.AirbyteStream, mode apiclient.SyncMode) bool {
+	for _, m := range stream.SupportedSyncModes {
+		if m == mode {
+			return true
+		}
+	}
+	return false
+}
+
+func catalogStreamNames(catalog *apiclient.AirbyteCatalog) []string {
+	names := []string{}
+	for _, cs := range catalog.Streams {
+		if cs.Stream != nil {
+			names = append(names, streamKey{Namespace: cs.Stream.Namespace, Name: cs.Stream.Name}.String())
+		}
+	}
+	return names
+}
+
+func expandStringList(raw []interface{}) []string {
+	list := []string{}
+	for _, v := range raw {
+		list = append(list, v.(string))
+	}
+	return list
+}
+
+func flattenStringList(list []string) []interface{} {
+	raw := []interface{}{}
+	for _, v := range list {
+		raw = append(raw, v)
+	}
+	return raw
+}
@@ -0,0 +1,36 @@
+package provider
+
+import (
+	"github.com/eabrouwer3/terraform-provider-airbyte/internal/apiclient"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func FlattenDestination(d *schema.ResourceData, dest *apiclient.DestinationRead) error {
+	if err := d.Set("id", dest.DestinationId); err != nil {
+		return err
+	}
+	if err := d.Set("name", dest.Name); err != nil {
+		return err
+	}
+	if err := d.Set("destination_definition_id", dest.DestinationDefinitionId); err != nil {
+		return err
+	}
+	if err := d.Set("workspace_id", dest.WorkspaceId); err != nil {
+		return err
+	}
+	if err := d.Set("destination_name", dest.DestinationName); err != nil {
+		return err
+	}
+	if err := d.Set("icon", dest.Icon); err != nil {
+		return err
+	}
+	config, err := flattenConnectionConfiguration(d, dest.ConnectionConfiguration)
+	if err != nil {
+		return err
+	}
+	if err := d.Set("connection_configuration", config); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"github.com/eabrouwer3/terraform-provider-airbyte/internal/apiclient"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func FlattenDestinationDefinition(d *schema.ResourceData, dd *apiclient.DestinationDefinitionRead) error {
+	if err := d.Set("id", dd.DestinationDefinitionId); err != nil {
+		return err
+	}
+	if err := d.Set("name", dd.Name); err != nil {
+		return err
+	}
+	if err := d.Set("docker_repository", dd.DockerRepository); err != nil {
+		return err
+	}
+	if err := d.Set("docker_image_tag", dd.DockerImageTag); err != nil {
+		return err
+	}
+	if err := d.Set("documentation_url", dd.DocumentationUrl); err != nil {
+		return err
+	}
+	if dd.Icon != "" {
+		if err := d.Set("icon", dd.Icon); err != nil {
+			return err
+		}
+	}
+	if dd.ProtocolVersion != "" {
+		if err := d.Set("protocol_version", dd.ProtocolVersion); err != nil {
+			return err
+		}
+	}
+	if dd.ReleaseStage != "" {
+		if err := d.Set("release_stage", string(dd.ReleaseStage)); err != nil {
+			return err
+		}
+	}
+	if dd.ReleaseDate != "" {
+		if err := d.Set("release_date", dd.ReleaseDate); err != nil {
+			return err
+		}
+	}
+	if err := d.Set("supports_dbt", dd.SupportsDbt); err != nil {
+		return err
+	}
+	if err := d.Set("supports_normalization", dd.NormalizationConfig.Supported); err != nil {
+		return err
+	}
+	if err := d.Set("default_resource_requirements", flattenDefaultReqs(dd.ResourceRequirements)); err != nil {
+		return err
+	}
+	if err := d.Set("job_specific_resource_requirements", flattenJobSpecReqs(dd.ResourceRequirements)); err != nil {
+		return err
+	}
+
+	return nil
+}
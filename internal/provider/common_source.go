@@ -1,11 +1,14 @@
 package provider
 
 import (
+	"encoding/json"
+	"strconv"
+
 	"github.com/eabrouwer3/terraform-provider-airbyte/internal/apiclient"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
-func FlattenSource(d *schema.ResourceData, s *apiclient.Source) error {
+func FlattenSource(d *schema.ResourceData, s *apiclient.SourceRead) error {
 	if err := d.Set("id", s.SourceId); err != nil {
 		return err
 	}
@@ -24,9 +27,138 @@ func FlattenSource(d *schema.ResourceData, s *apiclient.Source) error {
 	if err := d.Set("icon", s.Icon); err != nil {
 		return err
 	}
-	if err := d.Set("connection_configuration", s.ConnectionConfiguration); err != nil {
+	config, err := flattenConnectionConfiguration(d, s.ConnectionConfiguration)
+	if err != nil {
+		return err
+	}
+	if err := d.Set("connection_configuration", config); err != nil {
 		return err
 	}
 
 	return nil
 }
+
+// expandConnectionConfiguration turns the strings of connection_configuration into the types the
+// connector specification gives its properties, so that a port is sent as a number and an SSH tunnel
+// as an object. Values that don't parse as their type, and all of them when the spec is unknown, are
+// sent as they are written.
+func expandConnectionConfiguration(d *schema.ResourceData, spec map[string]any) map[string]any {
+	properties, _ := spec["properties"].(map[string]any)
+
+	config := connectionConfiguration(d)
+	expanded := make(map[string]any, len(config))
+	for k, v := range config {
+		property, _ := properties[k].(map[string]any)
+		expanded[k] = expandConfigurationValue(v.(string), propertyTypes(property))
+	}
+
+	return expanded
+}
+
+func expandConfigurationValue(s string, types []string) any {
+	for _, t := range types {
+		if t == "string" {
+			return s
+		}
+	}
+
+	for _, t := range types {
+		switch t {
+		case "integer":
+			if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+				return i
+			}
+		case "number":
+			if f, err := strconv.ParseFloat(s, 64); err == nil {
+				return f
+			}
+		case "boolean":
+			if b, err := strconv.ParseBool(s); err == nil {
+				return b
+			}
+		case "object", "array":
+			var v any
+			if err := json.Unmarshal([]byte(s), &v); err == nil {
+				return v
+			}
+		}
+	}
+
+	return s
+}
+
+// propertyTypes lists the JSON schema types of a property besides null. The type may be a single one
+// or a list, and properties without one that choose between schemas with oneOf are objects.
+func propertyTypes(property map[string]any) []string {
+	switch t := property["type"].(type) {
+	case string:
+		return []string{t}
+	case []any:
+		var types []string
+		for _, e := range t {
+			if s, ok := e.(string); ok && s != "null" {
+				types = append(types, s)
+			}
+		}
+		return types
+	}
+	if _, ok := property["oneOf"]; ok {
+		return []string{"object"}
+	}
+	return nil
+}
+
+// flattenConnectionConfiguration turns a configuration read from the API into the strings
+// connection_configuration holds: numbers and booleans as they are written in HCL, and objects and
+// lists as JSON. Airbyte masks secrets in what it returns, so the value Terraform already knows is
+// kept for those, including secrets nested in objects.
+func flattenConnectionConfiguration(d *schema.ResourceData, config map[string]any) (map[string]any, error) {
+	known, _ := d.Get("connection_configuration").(map[string]any)
+
+	flat := make(map[string]any, len(config))
+	for k, v := range config {
+		if v == nil {
+			continue
+		}
+
+		var knownValue any
+		if s, ok := known[k].(string); ok {
+			knownValue = s
+			if _, isObject := v.(map[string]any); isObject {
+				knownValue = nil
+				_ = json.Unmarshal([]byte(s), &knownValue)
+			}
+		}
+
+		switch val := unmaskSecrets(v, knownValue).(type) {
+		case string:
+			flat[k] = val
+		default:
+			data, err := json.Marshal(val)
+			if err != nil {
+				return nil, err
+			}
+			flat[k] = string(data)
+		}
+	}
+
+	return flat, nil
+}
+
+// unmaskSecrets replaces the masked values in v with the ones at the same place in known.
+func unmaskSecrets(v any, known any) any {
+	switch val := v.(type) {
+	case string:
+		if val == apiclient.RedactedValue && known != nil {
+			return known
+		}
+	case map[string]any:
+		knownObject, _ := known.(map[string]any)
+		unmasked := make(map[string]any, len(val))
+		for k, e := range val {
+			unmasked[k] = unmaskSecrets(e, knownObject[k])
+		}
+		return unmasked
+	}
+	return v
+}
@@ -3,9 +3,10 @@ package provider
 import (
 	"github.com/eabrouwer3/terraform-provider-airbyte/internal/apiclient"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
-func FlattenSourceDefinition(d *schema.ResourceData, sd *apiclient.SourceDefinition) error {
+func FlattenSourceDefinition(d *schema.ResourceData, sd *apiclient.SourceDefinitionRead) error {
 	if err := d.Set("id", sd.SourceDefinitionId); err != nil {
 		return err
 	}
@@ -32,7 +33,7 @@ func FlattenSourceDefinition(d *schema.ResourceData, sd *apiclient.SourceDefinit
 		}
 	}
 	if sd.ReleaseStage != "" {
-		if err := d.Set("release_stage", sd.ReleaseStage); err != nil {
+		if err := d.Set("release_stage", string(sd.ReleaseStage)); err != nil {
 			return err
 		}
 	}
@@ -42,7 +43,7 @@ func FlattenSourceDefinition(d *schema.ResourceData, sd *apiclient.SourceDefinit
 		}
 	}
 	if sd.SourceType != "" {
-		if err := d.Set("source_type", sd.SourceType); err != nil {
+		if err := d.Set("source_type", string(sd.SourceType)); err != nil {
 			return err
 		}
 	}
@@ -56,18 +57,18 @@ func FlattenSourceDefinition(d *schema.ResourceData, sd *apiclient.SourceDefinit
 	return nil
 }
 
-func flattenDefaultReqs(reqs *apiclient.ResourceRequirements) []interface{} {
+func flattenDefaultReqs(reqs *apiclient.ActorDefinitionResourceRequirements) []interface{} {
 	if reqs != nil {
 		rawDefaultReqs := reqs.Default
 		if rawDefaultReqs != nil {
 			defaultReqs := make([]interface{}, 1, 1)
 			req := make(map[string]interface{})
 
-			if rawDefaultReqs.CPURequest != "" {
-				req["cpu_request"] = rawDefaultReqs.CPURequest
+			if rawDefaultReqs.CpuRequest != "" {
+				req["cpu_request"] = rawDefaultReqs.CpuRequest
 			}
-			if rawDefaultReqs.CPULimit != "" {
-				req["cpu_limit"] = rawDefaultReqs.CPULimit
+			if rawDefaultReqs.CpuLimit != "" {
+				req["cpu_limit"] = rawDefaultReqs.CpuLimit
 			}
 			if rawDefaultReqs.MemoryRequest != "" {
 				req["memory_request"] = rawDefaultReqs.MemoryRequest
@@ -83,21 +84,21 @@ func flattenDefaultReqs(reqs *apiclient.ResourceRequirements) []interface{} {
 	return make([]interface{}, 0)
 }
 
-func flattenJobSpecReqs(reqs *apiclient.ResourceRequirements) []interface{} {
+func flattenJobSpecReqs(reqs *apiclient.ActorDefinitionResourceRequirements) []interface{} {
 	if reqs != nil {
 		rawJobSpecReqs := reqs.JobSpecific
 		if rawJobSpecReqs != nil {
-			reqs := make([]interface{}, len(*rawJobSpecReqs), len(*rawJobSpecReqs))
+			reqs := make([]interface{}, len(rawJobSpecReqs), len(rawJobSpecReqs))
 
-			for i, rawJobSpecReq := range *rawJobSpecReqs {
+			for i, rawJobSpecReq := range rawJobSpecReqs {
 				req := make(map[string]interface{})
 
-				req["job_type"] = rawJobSpecReq.JobType
-				if rawJobSpecReq.ResourceRequirements.CPURequest != "" {
-					req["cpu_request"] = rawJobSpecReq.ResourceRequirements.CPURequest
+				req["job_type"] = string(rawJobSpecReq.JobType)
+				if rawJobSpecReq.ResourceRequirements.CpuRequest != "" {
+					req["cpu_request"] = rawJobSpecReq.ResourceRequirements.CpuRequest
 				}
-				if rawJobSpecReq.ResourceRequirements.CPULimit != "" {
-					req["cpu_limit"] = rawJobSpecReq.ResourceRequirements.CPULimit
+				if rawJobSpecReq.ResourceRequirements.CpuLimit != "" {
+					req["cpu_limit"] = rawJobSpecReq.ResourceRequirements.CpuLimit
 				}
 				if rawJobSpecReq.ResourceRequirements.MemoryRequest != "" {
 					req["memory_request"] = rawJobSpecReq.ResourceRequirements.MemoryRequest
@@ -114,3 +115,201 @@ func flattenJobSpecReqs(reqs *apiclient.ResourceRequirements) []interface{} {
 	}
 	return make([]interface{}, 0)
 }
+
+// defaultResourceRequirementsSchema and jobSpecificResourceRequirementsSchema are shared by the
+// source and destination definition resources.
+func defaultResourceRequirementsSchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "Actor definition specific resource requirements. Ff default is set, these are the requirements " +
+			"that should be set for ALL jobs run for this actor definition. It is overridden by the job type specific " +
+			"configurations. If not set, the platform will use defaults. These values will be overridden by configuration " +
+			"at the connection level.",
+		Type:     schema.TypeList,
+		MaxItems: 1,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"cpu_request": {
+					Description: "CPU Requested",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"cpu_limit": {
+					Description: "CPU Limit",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"memory_request": {
+					Description: "Memory Requested",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"memory_limit": {
+					Description: "Memory Limit",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+			},
+		},
+	}
+}
+
+func jobSpecificResourceRequirementsSchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "Sets resource requirements for a specific job type for an actor definition. These values override " +
+			"the default, if both are set. These values will be overridden by configuration at the connection level.",
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"job_type": {
+					Description:  "Allowed: get_spec | check_connection | discover_schema | sync | reset_connection | connection_updater | replicate",
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringInSlice([]string{"get_spec", "check_connection", "discover_schema", "sync", "reset_connection", "connection_updater", "replicate"}, false),
+				},
+				"cpu_request": {
+					Description: "CPU Requested",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"cpu_limit": {
+					Description: "CPU Limit",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"memory_request": {
+					Description: "Memory Requested",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"memory_limit": {
+					Description: "Memory Limit",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceDefaultResourceRequirementsSchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "Actor definition specific resource requirements. Ff default is set, these are the requirements " +
+			"that should be set for ALL jobs run for this actor definition. It is overridden by the job type specific " +
+			"configurations. If not set, the platform will use defaults. These values will be overridden by configuration " +
+			"at the connection level.",
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"cpu_request": {
+					Description: "CPU Requested",
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+				"cpu_limit": {
+					Description: "CPU Limit",
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+				"memory_request": {
+					Description: "Memory Requested",
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+				"memory_limit": {
+					Description: "Memory Limit",
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceJobSpecificResourceRequirementsSchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "Sets resource requirements for a specific job type for an actor definition. These values override " +
+			"the default, if both are set. These values will be overridden by configuration at the connection level.",
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"job_type": {
+					Description: "Allowed: get_spec | check_connection | discover_schema | sync | reset_connection | connection_updater | replicate",
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+				"cpu_request": {
+					Description: "CPU Requested",
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+				"cpu_limit": {
+					Description: "CPU Limit",
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+				"memory_request": {
+					Description: "Memory Requested",
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+				"memory_limit": {
+					Description: "Memory Limit",
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+			},
+		},
+	}
+}
+
+// setReqFields builds the resource requirements from the configuration. Removed blocks produce empty
+// requirements, which reset the ones stored in Airbyte when sent on update.
+func setReqFields(d *schema.ResourceData) *apiclient.ActorDefinitionResourceRequirements {
+	reqs := apiclient.ActorDefinitionResourceRequirements{
+		JobSpecific: []apiclient.JobTypeResourceLimit{},
+	}
+
+	if defaultReqs, ok := d.Get("default_resource_requirements").([]interface{}); ok && len(defaultReqs) > 0 {
+		// An empty block is read as a nil element.
+		defaultReq, _ := defaultReqs[0].(map[string]interface{})
+		rr := expandResourceRequirements(defaultReq)
+		reqs.Default = &rr
+	}
+
+	if jobSpecReqs, ok := d.Get("job_specific_resource_requirements").([]interface{}); ok {
+		for _, rawJobSpec := range jobSpecReqs {
+			rjs, _ := rawJobSpec.(map[string]interface{})
+			jobType, _ := rjs["job_type"].(string)
+
+			reqs.JobSpecific = append(reqs.JobSpecific, apiclient.JobTypeResourceLimit{
+				JobType:              apiclient.JobType(jobType),
+				ResourceRequirements: expandResourceRequirements(rjs),
+			})
+		}
+	}
+
+	return &reqs
+}
+
+func expandResourceRequirements(raw map[string]interface{}) apiclient.ResourceRequirements {
+	rr := apiclient.ResourceRequirements{}
+
+	if v, ok := raw["cpu_request"].(string); ok {
+		rr.CpuRequest = v
+	}
+	if v, ok := raw["cpu_limit"].(string); ok {
+		rr.CpuLimit = v
+	}
+	if v, ok := raw["memory_request"].(string); ok {
+		rr.MemoryRequest = v
+	}
+	if v, ok := raw["memory_limit"].(string); ok {
+		rr.MemoryLimit = v
+	}
+
+	return rr
+}
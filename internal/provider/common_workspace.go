@@ -5,17 +5,15 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
-func FlattenWorkspace(d *schema.ResourceData, workspace *apiclient.Workspace) error {
+func FlattenWorkspace(d *schema.ResourceData, workspace *apiclient.WorkspaceRead) error {
 	if err := d.Set("id", workspace.WorkspaceId); err != nil {
 		return err
 	}
 	if err := d.Set("customer_id", workspace.CustomerId); err != nil {
 		return err
 	}
-	if workspace.Email != "" {
-		if err := d.Set("email", workspace.Email); err != nil {
-			return err
-		}
+	if err := d.Set("email", workspace.Email); err != nil {
+		return err
 	}
 	if err := d.Set("name", workspace.Name); err != nil {
 		return err
@@ -23,10 +21,8 @@ func FlattenWorkspace(d *schema.ResourceData, workspace *apiclient.Workspace) er
 	if err := d.Set("slug", workspace.Slug); err != nil {
 		return err
 	}
-	if workspace.InitialSetupComplete != nil {
-		if err := d.Set("initial_setup_complete", workspace.InitialSetupComplete); err != nil {
-			return err
-		}
+	if err := d.Set("initial_setup_complete", workspace.InitialSetupComplete); err != nil {
+		return err
 	}
 	if workspace.DisplaySetupWizard != nil {
 		if err := d.Set("display_setup_wizard", workspace.DisplaySetupWizard); err != nil {
@@ -48,7 +44,7 @@ func FlattenWorkspace(d *schema.ResourceData, workspace *apiclient.Workspace) er
 			return err
 		}
 	}
-	if err := d.Set("notification_config", flattenNotifications(&workspace.Notifications)); err != nil {
+	if err := d.Set("notification_config", flattenNotifications(d, &workspace.Notifications)); err != nil {
 		return err
 	}
 	if workspace.FirstCompletedSync != nil {
@@ -62,7 +58,7 @@ func FlattenWorkspace(d *schema.ResourceData, workspace *apiclient.Workspace) er
 		}
 	}
 	if workspace.DefaultGeography != "" {
-		if err := d.Set("default_geography", workspace.DefaultGeography); err != nil {
+		if err := d.Set("default_geography", string(workspace.DefaultGeography)); err != nil {
 			return err
 		}
 	}
@@ -70,17 +66,25 @@ func FlattenWorkspace(d *schema.ResourceData, workspace *apiclient.Workspace) er
 	return nil
 }
 
-func flattenNotifications(rawNotifs *[]apiclient.Notification) []interface{} {
+// flattenNotifications keeps the webhooks of the state where the response masks them, as the ones
+// replayed from cassettes do.
+func flattenNotifications(d *schema.ResourceData, rawNotifs *[]apiclient.Notification) []interface{} {
 	if rawNotifs != nil {
+		known, _ := d.Get("notification_config").([]interface{})
 		notifs := make([]interface{}, len(*rawNotifs), len(*rawNotifs))
 
 		for i, rawNotif := range *rawNotifs {
 			n := make(map[string]interface{})
 
-			n["notification_type"] = rawNotif.NotificationType
+			n["notification_type"] = string(rawNotif.NotificationType)
 			n["send_on_success"] = rawNotif.SendOnSuccess
 			n["send_on_failure"] = rawNotif.SendOnFailure
-			n["slack_webhook"] = rawNotif.SlackConfiguration.Webhook
+			if rawNotif.SlackConfiguration != nil {
+				n["slack_webhook"] = rawNotif.SlackConfiguration.Webhook
+				if rawNotif.SlackConfiguration.Webhook == apiclient.RedactedValue && i < len(known) && known[i] != nil {
+					n["slack_webhook"] = known[i].(map[string]interface{})["slack_webhook"]
+				}
+			}
 
 			notifs[i] = n
 		}
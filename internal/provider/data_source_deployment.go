@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/eabrouwer3/terraform-provider-airbyte/internal/apiclient"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceDeployment() *schema.Resource {
+	return &schema.Resource{
+		Description: "Get the metadata of the Airbyte deployment, including the server version",
+		ReadContext: dataSourceDeploymentRead,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Description: "Deployment ID",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"mode": {
+				Description: "Possible values: OSS | CLOUD",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"version": {
+				Description: "Airbyte server version",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"environment": {
+				Description: "Deployment environment, e.g. docker or kube",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceDeploymentRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*apiclient.ApiClient)
+	var diags diag.Diagnostics
+
+	dm, err := client.GetDeploymentMetadata(ctx)
+	if err != nil {
+		return apiErrorDiags(err)
+	}
+
+	if err := d.Set("mode", dm.Mode); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("version", dm.Version); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("environment", dm.Environment); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(dm.Id)
+
+	return diags
+}
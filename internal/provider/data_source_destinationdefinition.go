@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"context"
+	"github.com/eabrouwer3/terraform-provider-airbyte/internal/apiclient"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceDestinationDefinition() *schema.Resource {
+	return &schema.Resource{
+		Description: "Get an Airbyte Destination Definition by id",
+		ReadContext: dataSourceDestinationDefinitionRead,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Description: "Destination Definition ID",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"name": {
+				Description: "Destination Definition Name",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"docker_repository": {
+				Description: "Docker Repository URL (e.g. 112233445566.dkr.ecr.us-east-1.amazonaws.com/destination-custom) or DockerHub identifier (e.g. airbyte/destination-postgres)",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"docker_image_tag": {
+				Description: "Docker image tag",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"documentation_url": {
+				Description: "Documentation URL",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"icon": {
+				Description: "URL for the icon displayed in the UI",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"protocol_version": {
+				Description: "The Airbyte Protocol version supported by the connector",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"release_stage": {
+				Description: "Allowed: alpha | beta | generally_available | custom",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"release_date": {
+				Description: "The date when this connector was first released, in yyyy-mm-dd format",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"supports_dbt": {
+				Description: "Whether the destination can run custom dbt transformations",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"supports_normalization": {
+				Description: "Whether the destination supports basic normalization",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"default_resource_requirements":      dataSourceDefaultResourceRequirementsSchema(),
+			"job_specific_resource_requirements": dataSourceJobSpecificResourceRequirementsSchema(),
+		},
+	}
+}
+
+func dataSourceDestinationDefinitionRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*apiclient.ApiClient)
+	var diags diag.Diagnostics
+
+	ddId := d.Get("id").(string)
+
+	dd, err := client.GetDestinationDefinitionById(ctx, ddId)
+	if apiclient.IsNotFound(err) {
+		return diag.Errorf("Destination Definition with id %q not found", ddId)
+	}
+	if err != nil {
+		return apiErrorDiags(err)
+	}
+
+	err = FlattenDestinationDefinition(d, dd)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(ddId)
+
+	return diags
+}
@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/eabrouwer3/terraform-provider-airbyte/internal/apiclient"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceHealth() *schema.Resource {
+	return &schema.Resource{
+		Description: "Get the health of the Airbyte server, optionally waiting for it to become available",
+		ReadContext: dataSourceHealthRead,
+		Schema: map[string]*schema.Schema{
+			"wait_timeout": {
+				Description: "Number of seconds to wait for the server to become available. If 0, the health is read once.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     0,
+			},
+			"available": {
+				Description: "Is the server available. False when it can't be reached as well",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceHealthRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*apiclient.ApiClient)
+	var diags diag.Diagnostics
+
+	if timeout := d.Get("wait_timeout").(int); timeout > 0 {
+		err := client.WaitForHealthy(ctx, time.Duration(timeout)*time.Second, apiclient.DefaultHealthPollInterval)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	// A server that is down is reported as unavailable, so that it can be checked for without failing.
+	available := false
+	hcr, err := client.GetHealthCheck(ctx)
+	if err == nil {
+		available = hcr.Available
+	} else if apiclient.IsUnavailable(err) {
+		tflog.Debug(ctx, "Airbyte is unavailable", map[string]any{
+			"error": err.Error(),
+		})
+	} else {
+		return apiErrorDiags(err)
+	}
+
+	if err := d.Set("available", available); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(client.HostURL)
+
+	return diags
+}
@@ -0,0 +1,32 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/eabrouwer3/terraform-provider-airbyte/internal/apiclient"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataSourceHealth_unavailable(t *testing.T) {
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+	gateway := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer gateway.Close()
+
+	for name, url := range map[string]string{"down": down.URL, "bad gateway": gateway.URL} {
+		client := &apiclient.ApiClient{HostURL: url, HTTPClient: http.DefaultClient}
+		d := schema.TestResourceDataRaw(t, dataSourceHealth().Schema, map[string]any{"wait_timeout": 0})
+
+		if diags := dataSourceHealthRead(context.Background(), d, client); diags.HasError() {
+			t.Fatalf("%s: expected no error, got %#v", name, diags)
+		}
+		if d.Get("available").(bool) {
+			t.Errorf("%s: expected the server to be unavailable", name)
+		}
+	}
+}
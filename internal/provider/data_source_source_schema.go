@@ -0,0 +1,167 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/eabrouwer3/terraform-provider-airbyte/internal/apiclient"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceSourceSchema() *schema.Resource {
+	return &schema.Resource{
+		Description: "Discover the streams an Airbyte Source exposes, e.g. to build the stream blocks of a connection with for_each",
+		ReadContext: dataSourceSourceSchemaRead,
+
+		// Discovering runs the connector on the server, which isn't bounded by request_timeout.
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Description: "Source ID",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"source_id": {
+				Description: "ID of the Source to discover",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"disable_cache": {
+				Description: "Run a new discover job instead of returning the catalog Airbyte cached from the last one",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"catalog_id": {
+				Description: "ID of the discovered catalog",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"catalog_json": {
+				Description: "The discovered catalog as JSON, as Airbyte returned it, including the configuration Airbyte suggests for each stream",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"streams": {
+				Description: "Streams exposed by the source",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Description: "Name of the stream",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"namespace": {
+							Description: "Namespace of the stream, for sources that have them",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"json_schema": {
+							Description: "JSON schema of the stream's records, as JSON",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"supported_sync_modes": {
+							Description: "Sync modes the stream can be read with. Possible values: full_refresh | incremental",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"source_defined_cursor": {
+							Description: "Whether the source defines the cursor, in which case cursor_field is ignored",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+						"default_cursor_field": {
+							Description: "Path to the field used as cursor when none is configured",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"source_defined_primary_key": {
+							Description: "Paths to the fields making up the primary key defined by the source",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeList,
+								Elem: &schema.Schema{Type: schema.TypeString},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceSourceSchemaRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*apiclient.ApiClient)
+	var diags diag.Diagnostics
+
+	sourceId := d.Get("source_id").(string)
+
+	discovered, err := client.DiscoverSourceSchema(ctx, sourceId, d.Get("disable_cache").(bool))
+	if err != nil {
+		return apiErrorDiags(err)
+	}
+
+	streams, err := flattenDiscoveredStreams(discovered.Catalog)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("catalog_id", discovered.CatalogId); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("catalog_json", string(discovered.CatalogJSON)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("streams", streams); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(sourceId)
+
+	return diags
+}
+
+func flattenDiscoveredStreams(catalog *apiclient.AirbyteCatalog) ([]interface{}, error) {
+	streams := []interface{}{}
+	for _, cs := range catalog.Streams {
+		if cs.Stream == nil {
+			continue
+		}
+
+		jsonSchema, err := json.Marshal(cs.Stream.JsonSchema)
+		if err != nil {
+			return nil, err
+		}
+		syncModes := []interface{}{}
+		for _, m := range cs.Stream.SupportedSyncModes {
+			syncModes = append(syncModes, string(m))
+		}
+		primaryKey := []interface{}{}
+		for _, path := range cs.Stream.SourceDefinedPrimaryKey {
+			primaryKey = append(primaryKey, flattenStringList(path))
+		}
+
+		streams = append(streams, map[string]interface{}{
+			"name":                       cs.Stream.Name,
+			"namespace":                  cs.Stream.Namespace,
+			"json_schema":                string(jsonSchema),
+			"supported_sync_modes":       syncModes,
+			"source_defined_cursor":      cs.Stream.SourceDefinedCursor != nil && *cs.Stream.SourceDefinedCursor,
+			"default_cursor_field":       flattenStringList(cs.Stream.DefaultCursorField),
+			"source_defined_primary_key": primaryKey,
+		})
+	}
+
+	return streams, nil
+}
@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceSourceSchema_basic(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: cassetteProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceSourceSchema_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.airbyte_source_schema.faker", "id", "airbyte_source.basic", "id"),
+					resource.TestCheckResourceAttr("data.airbyte_source_schema.faker", "streams.#", "3"),
+					resource.TestCheckResourceAttr("data.airbyte_source_schema.faker", "streams.0.name", "users"),
+					resource.TestCheckResourceAttr("data.airbyte_source_schema.faker", "streams.0.source_defined_cursor", "true"),
+					resource.TestCheckResourceAttr("data.airbyte_source_schema.faker", "streams.0.default_cursor_field.0", "updated_at"),
+					resource.TestCheckResourceAttr("data.airbyte_source_schema.faker", "streams.0.source_defined_primary_key.0.0", "id"),
+					resource.TestCheckResourceAttr("data.airbyte_source_schema.faker", "streams.1.supported_sync_modes.#", "1"),
+					resource.TestMatchResourceAttr("data.airbyte_source_schema.faker", "catalog_json", regexp.MustCompile(`"name":"purchases"`)),
+					resource.TestCheckResourceAttr("airbyte_connection.discovered", "stream.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceSourceSchema_basic = testAccResourceConnection_endpoints + `
+data "airbyte_source_schema" "faker" {
+  source_id = airbyte_source.basic.id
+  disable_cache = true
+}
+
+resource "airbyte_connection" "discovered" {
+  name = "discovered_test"
+  source_id = airbyte_source.basic.id
+  destination_id = airbyte_destination.basic.id
+
+  dynamic "stream" {
+    for_each = {
+      for s in data.airbyte_source_schema.faker.streams : s.name => s
+      if contains(s.supported_sync_modes, "incremental")
+    }
+    content {
+      name = stream.value.name
+      namespace = stream.value.namespace
+      sync_mode = "incremental"
+      destination_sync_mode = length(stream.value.source_defined_primary_key) > 0 ? "append_dedup" : "append"
+    }
+  }
+}
+`
@@ -62,73 +62,8 @@ func dataSourceSourceDefinition() *schema.Resource {
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
-			"default_resource_requirements": {
-				Description: "Actor definition specific resource requirements. Ff default is set, these are the requirements " +
-					"that should be set for ALL jobs run for this actor definition. It is overridden by the job type specific " +
-					"configurations. If not set, the platform will use defaults. These values will be overridden by configuration " +
-					"at the connection level.",
-				Type:     schema.TypeList,
-				Computed: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"cpu_request": {
-							Description: "CPU Requested",
-							Type:        schema.TypeString,
-							Computed:    true,
-						},
-						"cpu_limit": {
-							Description: "CPU Limit",
-							Type:        schema.TypeString,
-							Computed:    true,
-						},
-						"memory_request": {
-							Description: "Memory Requested",
-							Type:        schema.TypeString,
-							Computed:    true,
-						},
-						"memory_limit": {
-							Description: "Memory Limit",
-							Type:        schema.TypeString,
-							Computed:    true,
-						},
-					},
-				},
-			},
-			"job_specific_resource_requirements": {
-				Description: "Sets resource requirements for a specific job type for an actor definition. These values override " +
-					"the default, if both are set. These values will be overridden by configuration at the connection level.",
-				Type:     schema.TypeList,
-				Computed: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"job_type": {
-							Description: "Allowed: get_spec | check_connection | discover_schema | sync | reset_connection | connection_updater | replicate",
-							Type:        schema.TypeString,
-							Computed:    true,
-						},
-						"cpu_request": {
-							Description: "CPU Requested",
-							Type:        schema.TypeString,
-							Computed:    true,
-						},
-						"cpu_limit": {
-							Description: "CPU Limit",
-							Type:        schema.TypeString,
-							Computed:    true,
-						},
-						"memory_request": {
-							Description: "Memory Requested",
-							Type:        schema.TypeString,
-							Computed:    true,
-						},
-						"memory_limit": {
-							Description: "Memory Limit",
-							Type:        schema.TypeString,
-							Computed:    true,
-						},
-					},
-				},
-			},
+			"default_resource_requirements":      dataSourceDefaultResourceRequirementsSchema(),
+			"job_specific_resource_requirements": dataSourceJobSpecificResourceRequirementsSchema(),
 		},
 	}
 }
@@ -139,9 +74,12 @@ func dataSourceSourceDefinitionRead(ctx context.Context, d *schema.ResourceData,
 
 	sdId := d.Get("id").(string)
 
-	sd, err := client.GetSourceDefinitionById(sdId)
+	sd, err := client.GetSourceDefinitionById(ctx, sdId)
+	if apiclient.IsNotFound(err) {
+		return diag.Errorf("Source Definition with id %q not found", sdId)
+	}
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiags(err)
 	}
 
 	err = FlattenSourceDefinition(d, sd)
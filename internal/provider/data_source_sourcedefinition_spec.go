@@ -23,9 +23,9 @@ func dataSourceSourceDefinitionSpecRead(ctx context.Context, d *schema.ResourceD
 
 	sdId := d.Get("id").(string)
 
-	sd, err := client.GetSourceDefinitionSpec(sdId)
+	sd, err := client.GetSourceDefinitionById(ctx, sdId)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiags(err)
 	}
 
 	err = FlattenSourceDefinition(d, sd)
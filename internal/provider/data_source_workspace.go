@@ -2,10 +2,7 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
 	"github.com/eabrouwer3/terraform-provider-airbyte/internal/apiclient"
-	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -128,28 +125,24 @@ func dataSourceWorkspaceRead(ctx context.Context, d *schema.ResourceData, meta a
 		return diag.Errorf("Only one of `id` and `slug` can be set")
 	}
 
-	var workspace *apiclient.Workspace
+	var workspace *apiclient.WorkspaceRead
 	var err error
 	if workspaceId != "" {
-		workspace, err = client.GetWorkspaceById(workspaceId)
+		workspace, err = client.GetWorkspaceById(ctx, workspaceId)
 	} else if slug != "" {
-		workspace, err = client.GetWorkspaceBySlug(slug)
+		workspace, err = client.GetWorkspaceBySlug(ctx, apiclient.SlugRequestBody{Slug: slug})
 	}
 
+	if apiclient.IsNotFound(err) || (err == nil && workspace.IsTombstoned()) {
+		if workspaceId != "" {
+			return diag.Errorf("Workspace with id %q not found", workspaceId)
+		}
+		return diag.Errorf("Workspace with slug %q not found", slug)
+	}
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiags(err)
 	}
 
-	tflog.Info(ctx, fmt.Sprintf("%b", workspace.InitialSetupComplete))
-	tflog.Info(ctx, fmt.Sprintf("%b", workspace.DisplaySetupWizard))
-	tflog.Info(ctx, fmt.Sprintf("%b", workspace.AnonymousDataCollection))
-	tflog.Info(ctx, fmt.Sprintf("%b", workspace.News))
-	tflog.Info(ctx, fmt.Sprintf("%b", workspace.SecurityUpdates))
-	tflog.Info(ctx, fmt.Sprintf("%b", workspace.FirstCompletedSync))
-	tflog.Info(ctx, fmt.Sprintf("%b", workspace.FeedbackDone))
-	stringW, _ := json.Marshal(workspace)
-	tflog.Info(ctx, string(stringW))
-
 	// Flatten workspace to schema
 	err = FlattenWorkspace(d, workspace)
 	if err != nil {
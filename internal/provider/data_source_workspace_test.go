@@ -8,9 +8,9 @@ import (
 )
 
 func TestAccDataSourceWorkspace_basic(t *testing.T) {
-	resource.Test(t, resource.TestCase{
+	resource.UnitTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
+		ProviderFactories: cassetteProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDataSourceWorkspace_basic,
@@ -28,9 +28,9 @@ func TestAccDataSourceWorkspace_basic(t *testing.T) {
 }
 
 func TestAccDataSourceWorkspace_complex(t *testing.T) {
-	resource.Test(t, resource.TestCase{
+	resource.UnitTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
+		ProviderFactories: cassetteProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDataSourceWorkspace_complex,
@@ -0,0 +1,133 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/eabrouwer3/terraform-provider-airbyte/internal/apiclient"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// mapAttributes are TypeMap attributes; path segments below them are map keys rather than attributes.
+var mapAttributes = map[string]bool{
+	"connection_configuration": true,
+}
+
+// renamedAttributes maps API property names to attribute names that don't follow from snake-casing them.
+var renamedAttributes = map[string]string{
+	"notifications":      "notification_config",
+	"sourceDefinitionId": "sourcedefinition_id",
+}
+
+var indexedSegment = regexp.MustCompile(`^(.+)\[(\d+)\]$`)
+
+// apiErrorDiags converts an error returned by the apiclient into diagnostics. Each 422 validation
+// error becomes its own diagnostic pointing at the attribute it refers to.
+func apiErrorDiags(err error) diag.Diagnostics {
+	var apiErr *apiclient.APIError
+	if !errors.As(err, &apiErr) || len(apiErr.ValidationErrors) == 0 {
+		return diag.FromErr(err)
+	}
+
+	var diags diag.Diagnostics
+	for _, ve := range apiErr.ValidationErrors {
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       fmt.Sprintf("Invalid value for %s: %s", ve.PropertyPath, ve.Message),
+			Detail:        fmt.Sprintf("Airbyte rejected the value %q: %s", ve.InvalidValue, apiErr.Message),
+			AttributePath: attributePath(ve.PropertyPath),
+		})
+	}
+
+	return diags
+}
+
+// attributePath turns an Airbyte propertyPath (e.g. `connectionConfiguration.start_date`, or
+// `$.start_date` for paths inside a connector configuration) into a Terraform attribute path.
+func attributePath(propertyPath string) cty.Path {
+	if strings.HasPrefix(propertyPath, "$.") {
+		propertyPath = "connectionConfiguration." + strings.TrimPrefix(propertyPath, "$.")
+	}
+
+	path := cty.Path{}
+	segments := strings.Split(propertyPath, ".")
+	for i, segment := range segments {
+		if segment == "" {
+			continue
+		}
+
+		index := -1
+		if m := indexedSegment.FindStringSubmatch(segment); m != nil {
+			segment = m[1]
+			index, _ = strconv.Atoi(m[2])
+		}
+
+		attr, ok := renamedAttributes[segment]
+		if !ok {
+			attr = toSnakeCase(segment)
+		}
+		path = path.GetAttr(attr)
+		if index >= 0 {
+			path = path.IndexInt(index)
+		}
+
+		if mapAttributes[attr] && i+1 < len(segments) {
+			return path.IndexString(segments[i+1])
+		}
+	}
+
+	return path
+}
+
+// removeFromState clears the ID of an object that was deleted outside of Terraform, so that it is
+// planned for recreation instead of failing every refresh.
+func removeFromState(d *schema.ResourceData, kind string) diag.Diagnostics {
+	id := d.Id()
+	d.SetId("")
+
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("%s %s no longer exists", kind, id),
+		Detail:   fmt.Sprintf("The %s was deleted outside of Terraform and has been removed from the state. It will be recreated on the next apply.", strings.ToLower(kind)),
+	}}
+}
+
+func toSnakeCase(s string) string {
+	var sb strings.Builder
+	for i, r := range s {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				sb.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// diagsError joins the errors among diags into one error, for CustomizeDiff functions, which can't
+// return diagnostics.
+func diagsError(diags diag.Diagnostics) error {
+	var msgs []string
+	for _, d := range diags {
+		if d.Severity != diag.Error {
+			continue
+		}
+		msg := d.Summary
+		if d.Detail != "" {
+			msg += ": " + d.Detail
+		}
+		msgs = append(msgs, msg)
+	}
+	if len(msgs) == 0 {
+		return nil
+	}
+	return errors.New(strings.Join(msgs, "\n"))
+}
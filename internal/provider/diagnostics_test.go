@@ -0,0 +1,43 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/eabrouwer3/terraform-provider-airbyte/internal/apiclient"
+	"github.com/hashicorp/go-cty/cty"
+)
+
+func TestAttributePath(t *testing.T) {
+	cases := map[string]cty.Path{
+		"connectionConfiguration.start_date": cty.GetAttrPath("connection_configuration").IndexString("start_date"),
+		"$.start_date":                       cty.GetAttrPath("connection_configuration").IndexString("start_date"),
+		"sourceDefinitionId":                 cty.GetAttrPath("sourcedefinition_id"),
+		"dockerImageTag":                     cty.GetAttrPath("docker_image_tag"),
+		"notifications[1].sendOnFailure":     cty.GetAttrPath("notification_config").IndexInt(1).GetAttr("send_on_failure"),
+	}
+
+	for propertyPath, expected := range cases {
+		if got := attributePath(propertyPath); !got.Equals(expected) {
+			t.Errorf("%s: expected %#v, got %#v", propertyPath, expected, got)
+		}
+	}
+}
+
+func TestApiErrorDiags(t *testing.T) {
+	err := &apiclient.APIError{
+		StatusCode: 422,
+		Message:    "Invalid input",
+		ValidationErrors: []apiclient.ValidationError{
+			{PropertyPath: "connectionConfiguration.start_date", InvalidValue: "yesterday", Message: "must be a date"},
+			{PropertyPath: "name", Message: "must not be blank"},
+		},
+	}
+
+	diags := apiErrorDiags(err)
+	if len(diags) != 2 {
+		t.Fatalf("expected 2 diagnostics, got %d", len(diags))
+	}
+	if !diags[1].AttributePath.Equals(cty.GetAttrPath("name")) {
+		t.Errorf("unexpected attribute path %#v", diags[1].AttributePath)
+	}
+}
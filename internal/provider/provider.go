@@ -7,6 +7,7 @@ import (
 
 	"github.com/eabrouwer3/terraform-provider-airbyte/internal/apiclient"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -36,31 +37,288 @@ func New(version string) func() *schema.Provider {
 					Optional:    true,
 					DefaultFunc: schema.EnvDefaultFunc("AIRBYTE_URL", "http://localhost:8000"),
 				},
+				"api_base_path": {
+					Description: "Path of the Airbyte API below `host_url`, e.g. `airbyte/api/v1` when Airbyte is served under a path prefix",
+					Type:        schema.TypeString,
+					Optional:    true,
+					DefaultFunc: schema.EnvDefaultFunc("AIRBYTE_API_BASE_PATH", apiclient.BASE_URL),
+				},
+				"http_headers": {
+					Description: "Extra HTTP headers sent with every request, e.g. for Cloudflare Access or GCP IAP",
+					Type:        schema.TypeMap,
+					Optional:    true,
+					Sensitive:   true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+				"proxy_url": {
+					Description: "URL of an HTTP proxy to send requests through. Defaults to the `HTTPS_PROXY`/`HTTP_PROXY` environment variables.",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"username": {
+					Description: "Username for HTTP basic auth (e.g. the nginx proxy in front of Airbyte OSS)",
+					Type:        schema.TypeString,
+					Optional:    true,
+					DefaultFunc: schema.EnvDefaultFunc("AIRBYTE_USERNAME", nil),
+				},
+				"password": {
+					Description: "Password for HTTP basic auth",
+					Type:        schema.TypeString,
+					Optional:    true,
+					Sensitive:   true,
+					DefaultFunc: schema.EnvDefaultFunc("AIRBYTE_PASSWORD", nil),
+				},
+				"client_id": {
+					Description:  "OAuth2 client ID used with the client credentials grant (Airbyte Cloud, or Keycloak for Self-Managed Enterprise)",
+					Type:         schema.TypeString,
+					Optional:     true,
+					DefaultFunc:  schema.EnvDefaultFunc("AIRBYTE_CLIENT_ID", nil),
+					RequiredWith: []string{"client_secret", "token_url"},
+				},
+				"client_secret": {
+					Description:  "OAuth2 client secret",
+					Type:         schema.TypeString,
+					Optional:     true,
+					Sensitive:    true,
+					DefaultFunc:  schema.EnvDefaultFunc("AIRBYTE_CLIENT_SECRET", nil),
+					RequiredWith: []string{"client_id", "token_url"},
+				},
+				"token_url": {
+					Description:  "OAuth2 token endpoint (e.g. https://keycloak.example.com/realms/airbyte/protocol/openid-connect/token)",
+					Type:         schema.TypeString,
+					Optional:     true,
+					DefaultFunc:  schema.EnvDefaultFunc("AIRBYTE_TOKEN_URL", nil),
+					RequiredWith: []string{"client_id", "client_secret"},
+				},
+				"bearer_token": {
+					Description:   "Static bearer token to send instead of fetching one with `client_id`/`client_secret`",
+					Type:          schema.TypeString,
+					Optional:      true,
+					Sensitive:     true,
+					DefaultFunc:   schema.EnvDefaultFunc("AIRBYTE_BEARER_TOKEN", nil),
+					ConflictsWith: []string{"client_id"},
+				},
+				"credential_process": {
+					Description: "Command to run to obtain a bearer token, modelled on the AWS CLI `credential_process`. " +
+						"It must print JSON like `{\"access_token\": \"...\", \"expiration\": \"2006-01-02T15:04:05Z\"}`; " +
+						"it is run again whenever the token expires.",
+					Type:          schema.TypeString,
+					Optional:      true,
+					DefaultFunc:   schema.EnvDefaultFunc("AIRBYTE_CREDENTIAL_PROCESS", nil),
+					ConflictsWith: []string{"client_id", "bearer_token"},
+				},
+				"max_retries": {
+					Description: "Maximum number of retries for requests failing with connection errors, 429 or 5xx responses",
+					Type:        schema.TypeInt,
+					Optional:    true,
+					Default:     apiclient.DefaultMaxRetries,
+				},
+				"retry_max_wait": {
+					Description: "Maximum number of seconds to wait between retries",
+					Type:        schema.TypeInt,
+					Optional:    true,
+					Default:     int(apiclient.DefaultRetryMaxWait / time.Second),
+				},
+				"request_timeout": {
+					Description: "Maximum number of seconds a single request may take. Requests that start connector " +
+						"containers, like discovering a schema, are only bounded by the resource or data source timeouts.",
+					Type:     schema.TypeInt,
+					Optional: true,
+					Default:  int(apiclient.DefaultRequestTimeout / time.Second),
+				},
+				"retry_mutating_requests": {
+					Description: "Also retry create, update and delete requests. By default only read requests are retried, " +
+						"since a mutating request that failed with a 5xx may still have been applied.",
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  false,
+				},
+				"ca_cert_file": {
+					Description:   "Path to a PEM file with CA certificates to trust in addition to the system pool",
+					Type:          schema.TypeString,
+					Optional:      true,
+					DefaultFunc:   schema.EnvDefaultFunc("AIRBYTE_CA_CERT_FILE", nil),
+					ConflictsWith: []string{"ca_cert_pem"},
+				},
+				"ca_cert_pem": {
+					Description:   "PEM-encoded CA certificates to trust in addition to the system pool",
+					Type:          schema.TypeString,
+					Optional:      true,
+					ConflictsWith: []string{"ca_cert_file"},
+				},
+				"client_cert": {
+					Description:  "Client certificate for mTLS, as PEM content or a path to a PEM file",
+					Type:         schema.TypeString,
+					Optional:     true,
+					DefaultFunc:  schema.EnvDefaultFunc("AIRBYTE_CLIENT_CERT", nil),
+					RequiredWith: []string{"client_key"},
+				},
+				"client_key": {
+					Description:  "Private key of the mTLS client certificate, as PEM content or a path to a PEM file",
+					Type:         schema.TypeString,
+					Optional:     true,
+					Sensitive:    true,
+					DefaultFunc:  schema.EnvDefaultFunc("AIRBYTE_CLIENT_KEY", nil),
+					RequiredWith: []string{"client_cert"},
+				},
+				"insecure_skip_verify": {
+					Description: "Skip verification of the server certificate. Only use this for testing.",
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+				},
+				"max_concurrent_requests": {
+					Description: "Maximum number of API requests in flight at once. 0 means unlimited.",
+					Type:        schema.TypeInt,
+					Optional:    true,
+					Default:     0,
+				},
+				"requests_per_second": {
+					Description: "Maximum number of API requests per second. 0 means unlimited.",
+					Type:        schema.TypeFloat,
+					Optional:    true,
+					Default:     0,
+				},
+				"max_concurrent_heavy_requests": {
+					Description: "Maximum number of heavyweight requests (definition creation, check_connection, discover_schema) " +
+						"in flight at once. These also count towards `max_concurrent_requests`. 0 means unlimited.",
+					Type:     schema.TypeInt,
+					Optional: true,
+					Default:  0,
+				},
+				"heavy_requests_per_second": {
+					Description: "Maximum number of heavyweight requests per second. 0 means unlimited.",
+					Type:        schema.TypeFloat,
+					Optional:    true,
+					Default:     0,
+				},
+				"enable_read_cache": {
+					Description: "Fill an in-memory cache from the list endpoints on the first read, and answer later reads " +
+						"from it. Speeds up refreshing workspaces with many sources.",
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  false,
+				},
+				"wait_for_healthy": {
+					Description: "Wait for the Airbyte health endpoint to report the server as available before doing anything else",
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+				},
+				"health_timeout": {
+					Description: "Maximum number of seconds to wait for Airbyte to become healthy when `wait_for_healthy` is set",
+					Type:        schema.TypeInt,
+					Optional:    true,
+					Default:     300,
+				},
 			},
 			DataSourcesMap: map[string]*schema.Resource{
-				"airbyte_workspace":        dataSourceWorkspace(),
-				"airbyte_sourcedefinition": dataSourceSourceDefinition(),
+				"airbyte_workspace":              dataSourceWorkspace(),
+				"airbyte_sourcedefinition":       dataSourceSourceDefinition(),
+				"airbyte_destination_definition": dataSourceDestinationDefinition(),
+				"airbyte_source_schema":          dataSourceSourceSchema(),
+				"airbyte_health":                 dataSourceHealth(),
+				"airbyte_deployment":             dataSourceDeployment(),
 			},
 			ResourcesMap: map[string]*schema.Resource{
-				"airbyte_workspace":        resourceWorkspace(),
-				"airbyte_sourcedefinition": resourceSourceDefinition(),
-				"airbyte_source":           resourceSource(),
+				"airbyte_workspace":              resourceWorkspace(),
+				"airbyte_sourcedefinition":       resourceSourceDefinition(),
+				"airbyte_source":                 resourceSource(),
+				"airbyte_destination_definition": resourceDestinationDefinition(),
+				"airbyte_destination":            resourceDestination(),
+				"airbyte_connection":             resourceConnection(),
 			},
 		}
 
-		p.ConfigureContextFunc = configure(version, p)
+		p.ConfigureContextFunc = configure(version, p, nil)
 
 		return p
 	}
 }
 
-func configure(version string, p *schema.Provider) func(context.Context, *schema.ResourceData) (any, diag.Diagnostics) {
+// configure builds the API client. wrapTransport, when set, wraps the HTTP transport; tests use it to
+// record and replay API calls.
+func configure(version string, p *schema.Provider, wrapTransport func(http.RoundTripper) http.RoundTripper) func(context.Context, *schema.ResourceData) (any, diag.Diagnostics) {
 	return func(ctx context.Context, d *schema.ResourceData) (any, diag.Diagnostics) {
+		ctx = apiclient.WithLogSubsystem(ctx)
+
 		host := d.Get("host_url").(string)
+		username := d.Get("username").(string)
+		password := d.Get("password").(string)
+
+		transport, err := apiclient.NewTransport(apiclient.TransportConfig{
+			CACertFile:         d.Get("ca_cert_file").(string),
+			CACertPEM:          d.Get("ca_cert_pem").(string),
+			ClientCert:         d.Get("client_cert").(string),
+			ClientKey:          d.Get("client_key").(string),
+			InsecureSkipVerify: d.Get("insecure_skip_verify").(bool),
+			ProxyURL:           d.Get("proxy_url").(string),
+		})
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+
+		var roundTripper http.RoundTripper = transport
+		if wrapTransport != nil {
+			roundTripper = wrapTransport(transport)
+		}
+
+		// Requests are bounded by request_timeout and by the context of each CRUD function, so that
+		// resource timeouts apply. The client itself has no timeout, which would cut discover jobs short.
+		httpClient := &http.Client{Transport: roundTripper}
+
+		headers := make(map[string]string)
+		for k, v := range d.Get("http_headers").(map[string]any) {
+			headers[k] = v.(string)
+		}
+
+		c := &apiclient.ApiClient{
+			HTTPClient:            httpClient,
+			HostURL:               host,
+			BasePath:              d.Get("api_base_path").(string),
+			Headers:               headers,
+			Username:              username,
+			Password:              password,
+			MaxRetries:            d.Get("max_retries").(int),
+			RetryMaxWait:          time.Duration(d.Get("retry_max_wait").(int)) * time.Second,
+			RetryMutatingRequests: d.Get("retry_mutating_requests").(bool),
+			RequestTimeout:        time.Duration(d.Get("request_timeout").(int)) * time.Second,
+			Limiter:               apiclient.NewLimiter(d.Get("max_concurrent_requests").(int), d.Get("requests_per_second").(float64)),
+			HeavyLimiter:          apiclient.NewLimiter(d.Get("max_concurrent_heavy_requests").(int), d.Get("heavy_requests_per_second").(float64)),
+		}
+
+		if v, ok := d.GetOk("credential_process"); ok {
+			c.TokenSource = apiclient.NewCachingTokenSource(&apiclient.CredentialProcessTokenSource{
+				Command: v.(string),
+			})
+		} else if v, ok := d.GetOk("bearer_token"); ok {
+			c.TokenSource = &apiclient.StaticTokenSource{AccessToken: v.(string)}
+		} else if v, ok := d.GetOk("client_id"); ok {
+			c.TokenSource = apiclient.NewCachingTokenSource(&apiclient.ClientCredentialsTokenSource{
+				HTTPClient:   httpClient,
+				TokenURL:     d.Get("token_url").(string),
+				ClientId:     v.(string),
+				ClientSecret: d.Get("client_secret").(string),
+			})
+		}
+
+		if d.Get("enable_read_cache").(bool) {
+			c.ReadCache = apiclient.NewReadCache()
+		}
+
+		if d.Get("wait_for_healthy").(bool) {
+			timeout := time.Duration(d.Get("health_timeout").(int)) * time.Second
+			if err := c.WaitForHealthy(ctx, timeout, apiclient.DefaultHealthPollInterval); err != nil {
+				return nil, diag.FromErr(err)
+			}
+		}
+
+		if err := c.DetectServerVersion(ctx); err != nil {
+			tflog.Warn(ctx, "Unable to detect the Airbyte server version, assuming a recent release", map[string]any{
+				"error": err.Error(),
+			})
+		}
 
-		return &apiclient.ApiClient{
-			HTTPClient: &http.Client{Timeout: 120 * time.Second},
-			HostURL:    host,
-		}, nil
+		return c, nil
 	}
 }
@@ -2,9 +2,18 @@ package provider
 
 import (
 	"context"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
 
+	"github.com/eabrouwer3/terraform-provider-airbyte/internal/apiclient"
+
+	"github.com/eabrouwer3/terraform-provider-airbyte/internal/fakeairbyte"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -17,18 +26,213 @@ var providerFactories = map[string]func() (*schema.Provider, error){
 	},
 }
 
+// testAccRecorders are the recorders of the running tests by test name, so that the providers
+// Terraform starts and the clients of pre-checks and destroy checks share a cassette.
+var (
+	testAccRecordersMu sync.Mutex
+	testAccRecorders   = map[string]*apiclient.Recorder{}
+)
+
+// testAccTransport wraps the transport of t's API clients in a recorder using the cassette
+// testdata/cassettes/<test name>.json. With AIRBYTE_RECORDER_MODE=record the calls made to
+// AIRBYTE_URL are saved to it, and with AIRBYTE_RECORDER_MODE=replay tests without one fail.
+// Otherwise an existing cassette is replayed, and tests without one talk to AIRBYTE_URL directly,
+// in which case nil is returned.
+func testAccTransport(t *testing.T) func(http.RoundTripper) http.RoundTripper {
+	testAccRecordersMu.Lock()
+	defer testAccRecordersMu.Unlock()
+
+	rec, ok := testAccRecorders[t.Name()]
+	if !ok {
+		path := filepath.Join("testdata", "cassettes", t.Name()+".json")
+		_, statErr := os.Stat(path)
+
+		mode := apiclient.RecorderMode(os.Getenv("AIRBYTE_RECORDER_MODE"))
+		switch {
+		case mode == apiclient.ReplayMode && statErr != nil:
+			t.Fatalf("no cassette recorded at %s", path)
+		case mode == "" && statErr != nil:
+			return nil
+		case mode == "":
+			mode = apiclient.ReplayMode
+		}
+
+		var err error
+		rec, err = apiclient.NewRecorder(path, mode, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		testAccRecorders[t.Name()] = rec
+
+		t.Cleanup(func() {
+			testAccRecordersMu.Lock()
+			delete(testAccRecorders, t.Name())
+			testAccRecordersMu.Unlock()
+
+			if err := rec.Save(); err != nil {
+				t.Errorf("unable to save cassette: %s", err)
+			}
+		})
+	}
+
+	return func(transport http.RoundTripper) http.RoundTripper {
+		rec.Transport = transport
+		return rec
+	}
+}
+
+// cassetteProviderFactories are providerFactories whose API calls go through the recorder of
+// testAccTransport.
+func cassetteProviderFactories(t *testing.T) map[string]func() (*schema.Provider, error) {
+	wrapTransport := testAccTransport(t)
+	if wrapTransport == nil {
+		return providerFactories
+	}
+
+	return map[string]func() (*schema.Provider, error){
+		"airbyte": func() (*schema.Provider, error) {
+			p := New("dev")()
+			// Terraform starts a provider per command; they all share the test's recording.
+			p.ConfigureContextFunc = configure("dev", p, wrapTransport)
+			return p, nil
+		},
+	}
+}
+
+// testAccClient returns a client configured like the providers of cassetteProviderFactories, for
+// pre-checks and for checking what is left once Terraform has destroyed everything.
+func testAccClient(t *testing.T) *apiclient.ApiClient {
+	p := New("dev")()
+	p.ConfigureContextFunc = configure("dev", p, testAccTransport(t))
+
+	if diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(nil)); diags.HasError() {
+		t.Fatalf("unable to configure the provider: %#v", diags)
+	}
+
+	return p.Meta().(*apiclient.ApiClient)
+}
+
+// TestMain points the tests at an in-memory fake Airbyte unless AIRBYTE_URL names a real one, so
+// resource.UnitTest cases run anywhere.
+func TestMain(m *testing.M) {
+	if os.Getenv("AIRBYTE_URL") != "" {
+		os.Exit(m.Run())
+	}
+
+	srv := fakeairbyte.NewServer()
+	os.Setenv("AIRBYTE_URL", srv.URL)
+
+	code := m.Run()
+	srv.Close()
+	os.Exit(code)
+}
+
 func TestProvider(t *testing.T) {
 	if err := New("dev")().InternalValidate(); err != nil {
 		t.Fatalf("err: %s", err)
 	}
 }
 
-var testAccProvider *schema.Provider = New("dev")()
-
 func testAccPreCheck(t *testing.T) {
-	err := testAccProvider.Configure(context.Background(), terraform.NewResourceConfigRaw(nil))
+	testAccClient(t)
+}
+
+// newFakeClient returns a client for a fake Airbyte of its own, for tests that change objects behind
+// Terraform's back.
+func newFakeClient(t *testing.T) *apiclient.ApiClient {
+	srv := fakeairbyte.NewServer()
+	t.Cleanup(srv.Close)
+
+	return &apiclient.ApiClient{HostURL: srv.URL, HTTPClient: srv.Client()}
+}
+
+// testDriftRemovesFromState creates r from raw, lets drift change it outside of Terraform, and checks
+// that the next refresh removes it from the state so that the following plan creates it again.
+func testDriftRemovesFromState(t *testing.T, r *schema.Resource, raw map[string]any, client *apiclient.ApiClient, drift func(id string)) {
+	t.Helper()
+	ctx := context.Background()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, raw)
+	if diags := r.CreateContext(ctx, d, client); diags.HasError() {
+		t.Fatalf("unable to create: %#v", diags)
+	}
+	id := d.Id()
+
+	drift(id)
+
+	diags := r.ReadContext(ctx, d, client)
+	if diags.HasError() {
+		t.Fatalf("expected the refresh to succeed, got %#v", diags)
+	}
+	if len(diags) != 1 || !strings.Contains(diags[0].Summary, id+" no longer exists") {
+		t.Errorf("expected a warning that %s no longer exists, got %#v", id, diags)
+	}
+
+	state := d.State()
+	if state != nil {
+		t.Fatalf("expected %s to be removed from the state, got %#v", id, state)
+	}
+	plan, err := r.Diff(ctx, state, terraform.NewResourceConfigRaw(raw), client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plan == nil || plan.Attributes["id"] == nil || !plan.Attributes["id"].NewComputed {
+		t.Errorf("expected a plan creating a new object, got %#v", plan)
+	}
+}
+
+func TestCassetteReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	raw := map[string]any{
+		"name": "replay_test",
+		"notification_config": []any{map[string]any{
+			"notification_type": "slack",
+			"slack_webhook":     "https://hooks.slack.com/services/T000/B000/XXXX",
+			"send_on_failure":   true,
+		}},
+	}
+
+	run := func(mode apiclient.RecorderMode, hostURL string, transport http.RoundTripper) map[string]string {
+		rec, err := apiclient.NewRecorder(path, mode, transport)
+		if err != nil {
+			t.Fatal(err)
+		}
+		client := &apiclient.ApiClient{HostURL: hostURL, HTTPClient: &http.Client{Transport: rec}}
 
+		r := resourceWorkspace()
+		d := schema.TestResourceDataRaw(t, r.Schema, raw)
+		if diags := r.CreateContext(context.Background(), d, client); diags.HasError() {
+			t.Fatalf("%s: %#v", mode, diags)
+		}
+		if diags := r.ReadContext(context.Background(), d, client); diags.HasError() {
+			t.Fatalf("%s: %#v", mode, diags)
+		}
+		if err := rec.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		return d.State().Attributes
+	}
+
+	srv := fakeairbyte.NewServer()
+	recorded := run(apiclient.RecordMode, srv.URL, srv.Client().Transport)
+	srv.Close()
+	replayed := run(apiclient.ReplayMode, "http://replay.invalid", nil)
+
+	webhook := raw["notification_config"].([]any)[0].(map[string]any)["slack_webhook"].(string)
+	data, err := os.ReadFile(path)
 	if err != nil {
 		t.Fatal(err)
 	}
+	if strings.Contains(string(data), webhook) || !strings.Contains(string(data), `"webhook\":\"`+apiclient.RedactedValue) {
+		t.Errorf("expected the webhook to be masked in the cassette:\n%s", data)
+	}
+	if replayed["notification_config.0.slack_webhook"] != webhook {
+		t.Errorf("expected the configured webhook to be kept, got %v", replayed)
+	}
+	for k, v := range recorded {
+		if replayed[k] != v {
+			t.Errorf("%s: recorded %q, replayed %q", k, v, replayed[k])
+		}
+	}
 }
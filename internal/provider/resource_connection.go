@@ -0,0 +1,589 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/eabrouwer3/terraform-provider-airbyte/internal/apiclient"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceConnection() *schema.Resource {
+	return &schema.Resource{
+		// This description is used by the documentation generator and the language server.
+		Description: "Airbyte Connection, which syncs a source into a destination",
+
+		CreateContext: resourceConnectionCreate,
+		ReadContext:   resourceConnectionRead,
+		UpdateContext: resourceConnectionUpdate,
+		DeleteContext: resourceConnectionDelete,
+
+		CustomizeDiff: resourceConnectionCustomizeDiff,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Description: "Connection ID",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"name": {
+				Description: "Name of the Connection",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"source_id": {
+				Description: "ID of the Source to sync from",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"destination_id": {
+				Description: "ID of the Destination to sync to",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"namespace_definition": {
+				Description:  "Where the namespace of the data written to the destination comes from. Allowed: source | destination | customformat",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "source",
+				ValidateFunc: validation.StringInSlice([]string{"source", "destination", "customformat"}, false),
+			},
+			"namespace_format": {
+				Description:      "Namespace to write to when namespace_definition is customformat. ${SOURCE_NAMESPACE} is replaced with the namespace of the source stream (e.g. `airbyte_${SOURCE_NAMESPACE}`)",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validateNamespaceFormat,
+			},
+			"prefix": {
+				Description: "Prefix prepended to the name of every stream written to the destination",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"status": {
+				Description:  "Allowed: active | inactive",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "active",
+				ValidateFunc: validation.StringInSlice([]string{"active", "inactive"}, false),
+			},
+			"geography": {
+				Description:  "Where the data is processed. Defaults to the default_geography of the workspace. Possible values: auto | us | eu",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"auto", "us", "eu"}, false),
+			},
+			"schedule": {
+				Description: "When the connection syncs. Without a schedule, it only syncs when triggered manually",
+				Type:        schema.TypeList,
+				MaxItems:    1,
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"schedule_type": {
+							Description:  "Allowed: manual | basic | cron",
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice([]string{"manual", "basic", "cron"}, false),
+						},
+						"units": {
+							Description:  "Number of time_units between syncs, for basic schedules",
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+						"time_unit": {
+							Description:  "Unit of the interval between syncs, for basic schedules. Allowed: minutes | hours | days | weeks | months",
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringInSlice([]string{"minutes", "hours", "days", "weeks", "months"}, false),
+						},
+						"cron_expression": {
+							Description: "Quartz cron expression, for cron schedules (e.g. `0 0 12 * * ?`)",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						"cron_time_zone": {
+							Description: "Time zone of the cron expression, for cron schedules. Defaults to UTC",
+							Type:        schema.TypeString,
+							Optional:    true,
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"stream": {
+				Description: "Sync configuration of a stream of the source, identified by its name and namespace. It is merged with the catalog discovered from the source; streams without a block follow stream_defaults",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Description: "Name of the stream",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"namespace": {
+							Description: "Namespace of the stream, for sources that have them",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						"selected": {
+							Description: "Whether the stream is synced",
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+						},
+						"sync_mode": {
+							Description:  "How the stream is read from the source. Defaults to the discovered sync mode. Allowed: full_refresh | incremental",
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringInSlice([]string{"full_refresh", "incremental"}, false),
+						},
+						"destination_sync_mode": {
+							Description:  "How the stream is written to the destination. Defaults to the discovered destination sync mode. Allowed: append | overwrite | append_dedup",
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringInSlice([]string{"append", "overwrite", "append_dedup"}, false),
+						},
+						"cursor_field": {
+							Description: "Path to the field used as cursor by incremental syncs. Defaults to the cursor defined by the source",
+							Type:        schema.TypeList,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"primary_key": {
+							Description: "Paths to the fields making up the primary key, used by append_dedup (e.g. `[[\"id\"]]`). Defaults to the primary key defined by the source",
+							Type:        schema.TypeList,
+							Optional:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeList,
+								Elem: &schema.Schema{Type: schema.TypeString},
+							},
+						},
+						"selected_fields": {
+							Description: "Top-level fields to sync. All fields are synced when empty",
+							Type:        schema.TypeList,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+			"stream_defaults": {
+				Description: "Sync configuration of the streams without a stream block. Without it, those streams aren't synced",
+				Type:        schema.TypeList,
+				MaxItems:    1,
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"selected": {
+							Description: "Whether the streams are synced",
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+						},
+						"sync_mode": {
+							Description:  "Sync mode of the streams that support it. The others keep their discovered sync mode. Allowed: full_refresh | incremental",
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringInSlice([]string{"full_refresh", "incremental"}, false),
+						},
+						"destination_sync_mode": {
+							Description:  "Destination sync mode of the streams that support it. The others keep their discovered destination sync mode. Allowed: append | overwrite | append_dedup",
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringInSlice([]string{"append", "overwrite", "append_dedup"}, false),
+						},
+					},
+				},
+			},
+			"effective_streams": {
+				Description: "Sync configuration of every stream of the source, as stream and stream_defaults configure the discovered catalog. When either is set, changes made to any stream outside of Terraform show up in the plan",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Description: "Name of the stream",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"namespace": {
+							Description: "Namespace of the stream, for sources that have them",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"selected": {
+							Description: "Whether the stream is synced",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+						"sync_mode": {
+							Description: "How the stream is read from the source",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"destination_sync_mode": {
+							Description: "How the stream is written to the destination",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"cursor_field": {
+							Description: "Path to the field used as cursor by incremental syncs",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"primary_key": {
+							Description: "Paths to the fields making up the primary key",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeList,
+								Elem: &schema.Schema{Type: schema.TypeString},
+							},
+						},
+						"selected_fields": {
+							Description: "Top-level fields synced, empty when all of them are",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+var namespacePlaceholderPattern = regexp.MustCompile(`\$\{([^}]*)\}`)
+
+// namespacePlaceholders are the variables Airbyte substitutes in a custom namespace format.
+var namespacePlaceholders = []string{"SOURCE_NAMESPACE"}
+
+func validateNamespaceFormat(v any, path cty.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+	format := v.(string)
+
+	for _, m := range namespacePlaceholderPattern.FindAllStringSubmatch(format, -1) {
+		known := false
+		for _, p := range namespacePlaceholders {
+			known = known || m[1] == p
+		}
+		if !known {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       fmt.Sprintf("Unknown namespace placeholder %s", m[0]),
+				Detail:        fmt.Sprintf("Airbyte only replaces ${%s} in namespace_format.", strings.Join(namespacePlaceholders, "}, ${")),
+				AttributePath: path,
+			})
+		}
+	}
+
+	if strings.Contains(namespacePlaceholderPattern.ReplaceAllString(format, ""), "${") {
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       "Unterminated placeholder in namespace_format",
+			Detail:        fmt.Sprintf("%q opens a placeholder with ${ that isn't closed.", format),
+			AttributePath: path,
+		})
+	}
+
+	return diags
+}
+
+// resourceConnectionCustomizeDiff checks the combinations of attributes Airbyte would reject, so
+// they fail at plan time instead of halfway through an apply.
+func resourceConnectionCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta any) error {
+	if format, ok := d.GetOk("namespace_format"); ok && d.NewValueKnown("namespace_definition") {
+		if nsDef := d.Get("namespace_definition").(string); nsDef != "customformat" {
+			return fmt.Errorf("namespace_format %q is only used when namespace_definition is customformat, not %s", format, nsDef)
+		}
+	}
+
+	if d.NewValueKnown("stream") {
+		seen := map[streamKey]bool{}
+		for _, raw := range d.Get("stream").(*schema.Set).List() {
+			k := streamBlockKey(raw.(map[string]interface{}))
+			if seen[k] {
+				return fmt.Errorf("stream %s is configured more than once", k)
+			}
+			seen[k] = true
+		}
+	}
+
+	client := meta.(*apiclient.ApiClient)
+	if err := checkConnectionFeatures(d, client); err != nil {
+		return err
+	}
+
+	if err := planEffectiveStreams(ctx, d, client); err != nil {
+		return err
+	}
+
+	if !d.NewValueKnown("schedule") {
+		return nil
+	}
+	schedules := d.Get("schedule").([]interface{})
+	if len(schedules) == 0 || schedules[0] == nil {
+		return nil
+	}
+	schedule := schedules[0].(map[string]interface{})
+
+	switch schedule["schedule_type"].(string) {
+	case "basic":
+		if schedule["units"].(int) == 0 || schedule["time_unit"].(string) == "" {
+			return fmt.Errorf("basic schedules need units and time_unit")
+		}
+		if schedule["cron_expression"].(string) != "" {
+			return fmt.Errorf("cron_expression is only used by cron schedules")
+		}
+	case "cron":
+		if schedule["cron_expression"].(string) == "" {
+			return fmt.Errorf("cron schedules need a cron_expression")
+		}
+		if schedule["units"].(int) != 0 || schedule["time_unit"].(string) != "" {
+			return fmt.Errorf("units and time_unit are only used by basic schedules")
+		}
+	}
+
+	return nil
+}
+
+// planEffectiveStreams merges stream and stream_defaults with the catalog discovered from the source
+// at plan time, so that streams Airbyte can't sync fail the plan, and the plan shows the
+// configuration every stream will have, including streams changed outside of Terraform. Connections
+// configuring neither keep whatever catalog they have.
+func planEffectiveStreams(ctx context.Context, d *schema.ResourceDiff, client *apiclient.ApiClient) error {
+	if !d.NewValueKnown("stream") || !d.NewValueKnown("stream_defaults") {
+		return d.SetNewComputed("effective_streams")
+	}
+	streams := d.Get("stream").(*schema.Set).List()
+	defaults := d.Get("stream_defaults").([]interface{})
+	if len(streams) == 0 && len(defaults) == 0 {
+		return nil
+	}
+	// The source is usually created in the same apply, in which case the catalog is checked then.
+	if !d.NewValueKnown("source_id") {
+		return d.SetNewComputed("effective_streams")
+	}
+
+	discovered, err := client.DiscoverSourceSchema(ctx, d.Get("source_id").(string), false)
+	if err != nil {
+		return fmt.Errorf("unable to discover the streams of the source: %w", err)
+	}
+	merged, diags := mergeSyncCatalog(discovered.Catalog, streams, defaults)
+	if diags.HasError() {
+		return diagsError(diags)
+	}
+
+	return d.SetNew("effective_streams", flattenEffectiveStreams(merged))
+}
+
+// checkConnectionFeatures fails the plan when the configuration uses attributes the server is too
+// old for.
+func checkConnectionFeatures(d *schema.ResourceDiff, client *apiclient.ApiClient) error {
+	if _, ok := d.GetOk("geography"); ok && d.HasChange("geography") {
+		if err := client.RequireFeature(apiclient.FeatureConnectionGeography); err != nil {
+			return err
+		}
+	}
+	if schedules := d.Get("schedule").([]interface{}); len(schedules) > 0 && schedules[0] != nil {
+		scheduleType := schedules[0].(map[string]interface{})["schedule_type"].(string)
+		if apiclient.ConnectionScheduleType(scheduleType) == apiclient.ConnectionScheduleTypeCron {
+			if err := client.RequireFeature(apiclient.FeatureCronSchedules); err != nil {
+				return err
+			}
+		}
+	}
+	for _, raw := range d.Get("stream").(*schema.Set).List() {
+		if len(raw.(map[string]interface{})["selected_fields"].([]interface{})) == 0 {
+			continue
+		}
+		if err := client.RequireFeature(apiclient.FeatureFieldSelection); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// expandConnectionGeography returns the geography to send, leaving it out for servers from before
+// geographies, which don't know the field.
+func expandConnectionGeography(d *schema.ResourceData, client *apiclient.ApiClient) apiclient.Geography {
+	if v, ok := d.GetOk("geography"); ok && client.Supports(apiclient.FeatureConnectionGeography) {
+		return apiclient.Geography(v.(string))
+	}
+	return ""
+}
+
+// expandConnectionSchedule reads the schedule block; without one, the connection is manual.
+func expandConnectionSchedule(d *schema.ResourceData) (apiclient.ConnectionScheduleType, *apiclient.ConnectionScheduleData) {
+	schedules := d.Get("schedule").([]interface{})
+	if len(schedules) == 0 || schedules[0] == nil {
+		return apiclient.ConnectionScheduleTypeManual, nil
+	}
+	schedule := schedules[0].(map[string]interface{})
+
+	switch scheduleType := apiclient.ConnectionScheduleType(schedule["schedule_type"].(string)); scheduleType {
+	case apiclient.ConnectionScheduleTypeBasic:
+		return scheduleType, &apiclient.ConnectionScheduleData{
+			BasicSchedule: &apiclient.ConnectionScheduleDataBasicSchedule{
+				TimeUnit: schedule["time_unit"].(string),
+				Units:    int64(schedule["units"].(int)),
+			},
+		}
+	case apiclient.ConnectionScheduleTypeCron:
+		tz := schedule["cron_time_zone"].(string)
+		if tz == "" {
+			tz = "UTC"
+		}
+		return scheduleType, &apiclient.ConnectionScheduleData{
+			Cron: &apiclient.ConnectionScheduleDataCron{
+				CronExpression: schedule["cron_expression"].(string),
+				CronTimeZone:   tz,
+			},
+		}
+	default:
+		return apiclient.ConnectionScheduleTypeManual, nil
+	}
+}
+
+// expandSyncCatalog merges the stream configuration with the catalog discovered from the source.
+func expandSyncCatalog(ctx context.Context, d *schema.ResourceData, client *apiclient.ApiClient) (*apiclient.AirbyteCatalog, diag.Diagnostics) {
+	discovered, err := client.DiscoverSourceSchema(ctx, d.Get("source_id").(string), false)
+	if err != nil {
+		return nil, apiErrorDiags(err)
+	}
+
+	return mergeSyncCatalog(discovered.Catalog, d.Get("stream").(*schema.Set).List(), d.Get("stream_defaults").([]interface{}))
+}
+
+func resourceConnectionCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*apiclient.ApiClient)
+	var diags diag.Diagnostics
+
+	scheduleType, scheduleData := expandConnectionSchedule(d)
+	newConnection := apiclient.ConnectionCreate{
+		Name:                d.Get("name").(string),
+		SourceId:            d.Get("source_id").(string),
+		DestinationId:       d.Get("destination_id").(string),
+		NamespaceDefinition: apiclient.NamespaceDefinitionType(d.Get("namespace_definition").(string)),
+		NamespaceFormat:     d.Get("namespace_format").(string),
+		Prefix:              d.Get("prefix").(string),
+		Status:              apiclient.ConnectionStatus(d.Get("status").(string)),
+		ScheduleType:        scheduleType,
+		ScheduleData:        scheduleData,
+	}
+	newConnection.Geography = expandConnectionGeography(d, client)
+	if d.Get("stream").(*schema.Set).Len() > 0 || len(d.Get("stream_defaults").([]interface{})) > 0 {
+		syncCatalog, diags := expandSyncCatalog(ctx, d, client)
+		if diags.HasError() {
+			return diags
+		}
+		newConnection.SyncCatalog = syncCatalog
+	}
+
+	c, err := client.CreateConnection(ctx, newConnection)
+	if err != nil {
+		return apiErrorDiags(err)
+	}
+
+	d.SetId(c.ConnectionId)
+
+	resourceConnectionRead(ctx, d, meta)
+
+	return diags
+}
+
+func resourceConnectionRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*apiclient.ApiClient)
+
+	var diags diag.Diagnostics
+
+	connectionId := d.Id()
+
+	c, err := client.GetConnection(ctx, apiclient.ConnectionIdRequestBody{ConnectionId: connectionId})
+	if apiclient.IsNotFound(err) || (err == nil && c.Status == apiclient.ConnectionStatusDeprecated) {
+		return removeFromState(d, "Connection")
+	}
+	if err != nil {
+		return apiErrorDiags(err)
+	}
+
+	err = FlattenConnection(d, c)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func resourceConnectionUpdate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*apiclient.ApiClient)
+	var diags diag.Diagnostics
+
+	// Connection updates are patches, so the cleared values are sent explicitly.
+	namespaceFormat := d.Get("namespace_format").(string)
+	prefix := d.Get("prefix").(string)
+	scheduleType, scheduleData := expandConnectionSchedule(d)
+
+	updatedConnection := apiclient.ConnectionUpdate{
+		ConnectionId:        d.Id(),
+		Name:                d.Get("name").(string),
+		NamespaceDefinition: apiclient.NamespaceDefinitionType(d.Get("namespace_definition").(string)),
+		NamespaceFormat:     &namespaceFormat,
+		Prefix:              &prefix,
+		Status:              apiclient.ConnectionStatus(d.Get("status").(string)),
+		ScheduleType:        scheduleType,
+		ScheduleData:        scheduleData,
+	}
+	updatedConnection.Geography = expandConnectionGeography(d, client)
+	if d.HasChanges("stream", "stream_defaults", "effective_streams") {
+		syncCatalog, diags := expandSyncCatalog(ctx, d, client)
+		if diags.HasError() {
+			return diags
+		}
+		updatedConnection.SyncCatalog = syncCatalog
+	}
+
+	c, err := client.UpdateConnection(ctx, updatedConnection)
+	if err != nil {
+		return apiErrorDiags(err)
+	}
+
+	d.SetId(c.ConnectionId)
+
+	resourceConnectionRead(ctx, d, meta)
+
+	return diags
+}
+
+func resourceConnectionDelete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*apiclient.ApiClient)
+	var diags diag.Diagnostics
+
+	connectionId := d.Id()
+
+	err := client.DeleteConnection(ctx, apiclient.ConnectionIdRequestBody{ConnectionId: connectionId})
+	if err != nil && !apiclient.IsNotFound(err) {
+		return apiErrorDiags(err)
+	}
+
+	return diags
+}
@@ -0,0 +1,438 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/eabrouwer3/terraform-provider-airbyte/internal/apiclient"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResourceConnection_schedules(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: cassetteProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceConnection_cron,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("airbyte_connection.basic", "name", "basic_test"),
+					resource.TestCheckResourceAttr("airbyte_connection.basic", "status", "active"),
+					resource.TestCheckResourceAttr("airbyte_connection.basic", "namespace_definition", "source"),
+					resource.TestCheckResourceAttr("airbyte_connection.basic", "geography", "auto"),
+					resource.TestCheckResourceAttr("airbyte_connection.basic", "schedule.0.schedule_type", "cron"),
+					resource.TestCheckResourceAttr("airbyte_connection.basic", "schedule.0.cron_expression", "0 0 12 * * ?"),
+					resource.TestCheckResourceAttr("airbyte_connection.basic", "schedule.0.cron_time_zone", "UTC"),
+					resource.TestCheckResourceAttrPair("airbyte_connection.basic", "source_id", "airbyte_source.basic", "id"),
+				),
+			},
+			{
+				Config: testAccResourceConnection_basicSchedule,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("airbyte_connection.basic", "status", "inactive"),
+					resource.TestCheckResourceAttr("airbyte_connection.basic", "prefix", "faker_"),
+					resource.TestCheckResourceAttr("airbyte_connection.basic", "namespace_definition", "customformat"),
+					resource.TestCheckResourceAttr("airbyte_connection.basic", "namespace_format", "raw_${SOURCE_NAMESPACE}"),
+					resource.TestCheckResourceAttr("airbyte_connection.basic", "schedule.0.schedule_type", "basic"),
+					resource.TestCheckResourceAttr("airbyte_connection.basic", "schedule.0.units", "24"),
+					resource.TestCheckResourceAttr("airbyte_connection.basic", "schedule.0.time_unit", "hours"),
+				),
+			},
+			{
+				Config: testAccResourceConnection_manual,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("airbyte_connection.basic", "prefix", ""),
+					resource.TestCheckResourceAttr("airbyte_connection.basic", "namespace_format", ""),
+					resource.TestCheckResourceAttr("airbyte_connection.basic", "schedule.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceConnection_namespaceFormatRequiresCustomformat(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: cassetteProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceConnection_formatWithoutCustomformat,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("only used when namespace_definition is customformat"),
+			},
+		},
+	})
+}
+
+func TestAccResourceConnection_streams(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: cassetteProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceConnection_streams,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("airbyte_connection.basic", "stream.#", "2"),
+					resource.TestCheckResourceAttr("airbyte_connection.basic", "effective_streams.#", "3"),
+					resource.TestCheckTypeSetElemNestedAttrs("airbyte_connection.basic", "stream.*", map[string]string{
+						"name":                  "users",
+						"sync_mode":             "incremental",
+						"destination_sync_mode": "append_dedup",
+						"selected_fields.#":     "3",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("airbyte_connection.basic", "stream.*", map[string]string{
+						"name":     "products",
+						"selected": "false",
+					}),
+				),
+			},
+			{
+				Config:      testAccResourceConnection_unknownStream,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Stream orders not found"),
+			},
+		},
+	})
+}
+
+// newFakeEndpoints creates a Faker source, whose streams are users, products and purchases, and a
+// destination to connect it to.
+func newFakeEndpoints(t *testing.T, client *apiclient.ApiClient) (sourceId string, destinationId string) {
+	ctx := context.Background()
+
+	w, err := client.CreateWorkspace(ctx, apiclient.WorkspaceCreate{Name: "connection_test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	source, err := client.CreateSource(ctx, apiclient.SourceCreate{
+		Name:                    "faker",
+		SourceDefinitionId:      "dfd88b22-b603-4c3d-aad7-3701784586b1",
+		WorkspaceId:             w.WorkspaceId,
+		ConnectionConfiguration: map[string]any{"count": 10},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	destination, err := client.CreateDestination(ctx, apiclient.DestinationCreate{
+		Name:                    "local_json",
+		DestinationDefinitionId: "a625d593-bba5-4a1c-a53d-2d246268a816",
+		WorkspaceId:             w.WorkspaceId,
+		ConnectionConfiguration: map[string]any{"destination_path": "/local/connection"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return source.SourceId, destination.DestinationId
+}
+
+func TestResourceConnection_deprecated(t *testing.T) {
+	client := newFakeClient(t)
+	ctx := context.Background()
+	sourceId, destinationId := newFakeEndpoints(t, client)
+
+	raw := map[string]any{
+		"name":           "deprecated_test",
+		"source_id":      sourceId,
+		"destination_id": destinationId,
+	}
+	// Airbyte keeps deleted connections around with the deprecated status.
+	testDriftRemovesFromState(t, resourceConnection(), raw, client, func(id string) {
+		if err := client.DeleteConnection(ctx, apiclient.ConnectionIdRequestBody{ConnectionId: id}); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func TestResourceConnection_planStreams(t *testing.T) {
+	client := newFakeClient(t)
+	ctx := context.Background()
+	sourceId, destinationId := newFakeEndpoints(t, client)
+	r := resourceConnection()
+
+	raw := map[string]any{
+		"name":            "streams_test",
+		"source_id":       sourceId,
+		"destination_id":  destinationId,
+		"stream":          []any{map[string]any{"name": "users", "sync_mode": "incremental"}},
+		"stream_defaults": []any{map[string]any{"selected": false}},
+	}
+	config := terraform.NewResourceConfigRaw(raw)
+
+	plan, err := r.Diff(ctx, nil, config, client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]string{
+		"effective_streams.#":           "3",
+		"effective_streams.0.name":      "users",
+		"effective_streams.0.sync_mode": "incremental",
+		"effective_streams.1.name":      "products",
+		"effective_streams.1.selected":  "false",
+	}
+	for k, v := range expected {
+		if attr := plan.Attributes[k]; attr == nil || attr.New != v {
+			t.Errorf("expected the plan to set %s to %q, got %#v", k, v, attr)
+		}
+	}
+
+	d := schema.TestResourceDataRaw(t, r.Schema, raw)
+	if diags := r.CreateContext(ctx, d, client); diags.HasError() {
+		t.Fatalf("unable to create: %#v", diags)
+	}
+	if plan, err := r.Diff(ctx, d.State(), config, client); err != nil || plan != nil {
+		t.Errorf("expected no changes after creating, got %#v (%v)", plan, err)
+	}
+
+	// Someone selects products, which has no stream block, in the UI.
+	c, err := client.GetConnection(ctx, apiclient.ConnectionIdRequestBody{ConnectionId: d.Id()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	yes := true
+	c.SyncCatalog.Streams[1].Config.Selected = &yes
+	if _, err := client.UpdateConnection(ctx, apiclient.ConnectionUpdate{ConnectionId: d.Id(), SyncCatalog: &c.SyncCatalog}); err != nil {
+		t.Fatal(err)
+	}
+
+	if diags := r.ReadContext(ctx, d, client); diags.HasError() {
+		t.Fatalf("unable to refresh: %#v", diags)
+	}
+	plan, err = r.Diff(ctx, d.State(), config, client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if attr := plan.Attributes["effective_streams.1.selected"]; attr == nil || attr.Old != "true" || attr.New != "false" {
+		t.Errorf("expected the plan to unselect products again, got %#v", plan)
+	}
+
+	raw["stream"] = []any{map[string]any{"name": "orders"}}
+	if _, err := r.Diff(ctx, d.State(), terraform.NewResourceConfigRaw(raw), client); err == nil || !strings.Contains(err.Error(), "Stream orders not found") {
+		t.Errorf("expected the plan to fail on an unknown stream, got %v", err)
+	}
+}
+
+func TestResourceConnection_planRequiresFeatures(t *testing.T) {
+	ctx := context.Background()
+	r := resourceConnection()
+	client := &apiclient.ApiClient{ServerVersion: version.Must(version.NewVersion("0.39.0")), RawServerVersion: "0.39.0"}
+
+	cases := map[string]map[string]any{
+		"geography": {"geography": "us"},
+		"cron schedules": {"schedule": []any{map[string]any{
+			"schedule_type":   "cron",
+			"cron_expression": "0 0 12 * * ?",
+		}}},
+		"field selection": {"stream": []any{map[string]any{"name": "users", "selected_fields": []any{"id"}}}},
+	}
+	for name, raw := range cases {
+		raw["name"] = "features_test"
+		raw["source_id"] = "source"
+		raw["destination_id"] = "destination"
+		_, err := r.Diff(ctx, nil, terraform.NewResourceConfigRaw(raw), client)
+		if err == nil || !strings.Contains(err.Error(), "requires Airbyte >=") {
+			t.Errorf("%s: expected the plan to fail, got %v", name, err)
+		}
+	}
+}
+
+func TestExpandConnectionGeography(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceConnection().Schema, map[string]any{"name": "geo", "geography": "us"})
+
+	cases := map[string]apiclient.Geography{
+		"":        apiclient.GeographyUs,
+		"0.40.25": apiclient.GeographyUs,
+		"0.40.3":  "",
+	}
+	for v, expected := range cases {
+		client := &apiclient.ApiClient{}
+		if v != "" {
+			client.ServerVersion = version.Must(version.NewVersion(v))
+		}
+		if got := expandConnectionGeography(d, client); got != expected {
+			t.Errorf("%q: expected geography %q, got %q", v, expected, got)
+		}
+	}
+}
+
+func TestMergeSyncCatalog(t *testing.T) {
+	yes := true
+	discovered := &apiclient.AirbyteCatalog{Streams: []apiclient.AirbyteStreamAndConfiguration{
+		{Stream: &apiclient.AirbyteStream{
+			Name:                    "users",
+			Namespace:               "public",
+			SupportedSyncModes:      []apiclient.SyncMode{apiclient.SyncModeFullRefresh, apiclient.SyncModeIncremental},
+			SourceDefinedCursor:     &yes,
+			DefaultCursorField:      []string{"updated_at"},
+			SourceDefinedPrimaryKey: [][]string{{"id"}},
+		}},
+		{Stream: &apiclient.AirbyteStream{
+			Name:               "users",
+			Namespace:          "archive",
+			SupportedSyncModes: []apiclient.SyncMode{apiclient.SyncModeFullRefresh},
+		}},
+	}}
+	streams := []interface{}{map[string]interface{}{
+		"name":                  "users",
+		"namespace":             "public",
+		"selected":              true,
+		"sync_mode":             "",
+		"destination_sync_mode": "overwrite",
+		"cursor_field":          []interface{}{},
+		"primary_key":           []interface{}{},
+		"selected_fields":       []interface{}{},
+	}}
+	defaults := []interface{}{map[string]interface{}{
+		"selected":              true,
+		"sync_mode":             "incremental",
+		"destination_sync_mode": "append_dedup",
+	}}
+
+	merged, diags := mergeSyncCatalog(discovered, streams, defaults)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics %#v", diags)
+	}
+
+	public := merged.Streams[0].Config
+	if public.SyncMode != apiclient.SyncModeIncremental || public.DestinationSyncMode != apiclient.DestinationSyncModeOverwrite || !*public.Selected {
+		t.Errorf("expected the stream block over the defaults, got %+v", public)
+	}
+	// The archived stream can't sync incrementally or dedup without a primary key, so it keeps its
+	// discovered sync modes.
+	archive := merged.Streams[1].Config
+	if archive.SyncMode != apiclient.SyncModeFullRefresh || archive.DestinationSyncMode != apiclient.DestinationSyncModeAppend || !*archive.Selected {
+		t.Errorf("expected the discovered sync modes, got %+v", archive)
+	}
+
+	streams[0].(map[string]interface{})["namespace"] = "staging"
+	if _, diags := mergeSyncCatalog(discovered, streams, nil); len(diags) != 1 || diags[0].Summary != "Stream staging.users not found" {
+		t.Errorf("expected a missing stream, got %#v", diags)
+	}
+}
+
+func TestValidateNamespaceFormat(t *testing.T) {
+	cases := map[string]int{
+		"airbyte":                        0,
+		"${SOURCE_NAMESPACE}":            0,
+		"raw_${SOURCE_NAMESPACE}_v2":     0,
+		"${DESTINATION_NAMESPACE}":       1,
+		"${SOURCE_NAMESPACE}_${unknown}": 1,
+		"raw_${SOURCE_NAMESPACE":         1,
+		"${}":                            1,
+	}
+
+	for format, expected := range cases {
+		diags := validateNamespaceFormat(format, cty.GetAttrPath("namespace_format"))
+		if len(diags) != expected {
+			t.Errorf("%s: expected %d diagnostics, got %#v", format, expected, diags)
+		}
+	}
+}
+
+const testAccResourceConnection_endpoints = `
+resource "airbyte_workspace" "basic" {
+  name = "connection_test"
+}
+
+resource "airbyte_source" "basic" {
+  name = "faker"
+  sourcedefinition_id = "dfd88b22-b603-4c3d-aad7-3701784586b1"
+  workspace_id = airbyte_workspace.basic.id
+  connection_configuration = {
+    count = "10"
+  }
+}
+
+resource "airbyte_destination" "basic" {
+  name = "local_json"
+  destination_definition_id = "a625d593-bba5-4a1c-a53d-2d246268a816"
+  workspace_id = airbyte_workspace.basic.id
+  connection_configuration = {
+    destination_path = "/local/connection"
+  }
+}
+`
+
+const testAccResourceConnection_cron = testAccResourceConnection_endpoints + `
+resource "airbyte_connection" "basic" {
+  name = "basic_test"
+  source_id = airbyte_source.basic.id
+  destination_id = airbyte_destination.basic.id
+  schedule {
+    schedule_type = "cron"
+    cron_expression = "0 0 12 * * ?"
+  }
+}
+`
+
+const testAccResourceConnection_basicSchedule = testAccResourceConnection_endpoints + `
+resource "airbyte_connection" "basic" {
+  name = "basic_test"
+  source_id = airbyte_source.basic.id
+  destination_id = airbyte_destination.basic.id
+  status = "inactive"
+  prefix = "faker_"
+  namespace_definition = "customformat"
+  namespace_format = "raw_$${SOURCE_NAMESPACE}"
+  schedule {
+    schedule_type = "basic"
+    units = 24
+    time_unit = "hours"
+  }
+}
+`
+
+const testAccResourceConnection_manual = testAccResourceConnection_endpoints + `
+resource "airbyte_connection" "basic" {
+  name = "basic_test"
+  source_id = airbyte_source.basic.id
+  destination_id = airbyte_destination.basic.id
+}
+`
+
+const testAccResourceConnection_formatWithoutCustomformat = testAccResourceConnection_endpoints + `
+resource "airbyte_connection" "basic" {
+  name = "basic_test"
+  source_id = airbyte_source.basic.id
+  destination_id = airbyte_destination.basic.id
+  namespace_format = "raw"
+}
+`
+
+const testAccResourceConnection_streams = testAccResourceConnection_endpoints + `
+resource "airbyte_connection" "basic" {
+  name = "basic_test"
+  source_id = airbyte_source.basic.id
+  destination_id = airbyte_destination.basic.id
+  stream {
+    name = "users"
+    sync_mode = "incremental"
+    destination_sync_mode = "append_dedup"
+    selected_fields = ["id", "email", "updated_at"]
+  }
+  stream {
+    name = "products"
+    selected = false
+  }
+  stream_defaults {
+    selected = true
+  }
+}
+`
+
+const testAccResourceConnection_unknownStream = testAccResourceConnection_endpoints + `
+resource "airbyte_connection" "basic" {
+  name = "basic_test"
+  source_id = airbyte_source.basic.id
+  destination_id = airbyte_destination.basic.id
+  stream {
+    name = "orders"
+  }
+}
+`
@@ -0,0 +1,169 @@
+package provider
+
+import (
+	"context"
+	"github.com/eabrouwer3/terraform-provider-airbyte/internal/apiclient"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"time"
+)
+
+func resourceDestination() *schema.Resource {
+	return &schema.Resource{
+		// This description is used by the documentation generator and the language server.
+		Description: "Airbyte Destination",
+
+		CreateContext: resourceDestinationCreate,
+		ReadContext:   resourceDestinationRead,
+		UpdateContext: resourceDestinationUpdate,
+		DeleteContext: resourceDestinationDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Description: "Destination ID",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"destination_definition_id": {
+				Description: "Destination Definition ID",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"workspace_id": {
+				Description: "Workspace ID",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"name": {
+				Description: "Name of the Destination",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"destination_name": {
+				Description: "Name of the Destination Definition",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"icon": {
+				Description: "URL for the icon displayed in the UI",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"connection_configuration": {
+				Description: "Map of Credentials for the destination",
+				Type:        schema.TypeMap,
+				Required:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+// destinationSpecification is sourceSpecification for destination connectors.
+func destinationSpecification(ctx context.Context, client *apiclient.ApiClient, destinationDefinitionId string, workspaceId string) map[string]any {
+	spec, err := client.GetDestinationDefinitionSpecification(ctx, apiclient.DestinationDefinitionIdWithWorkspaceId{
+		DestinationDefinitionId: destinationDefinitionId,
+		WorkspaceId:             workspaceId,
+	})
+	if err != nil {
+		tflog.Warn(ctx, "Unable to fetch the destination definition specification, only well-known secret fields will be masked", map[string]any{
+			"error": err.Error(),
+		})
+		return nil
+	}
+	return spec.ConnectionSpecification
+}
+
+func resourceDestinationCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*apiclient.ApiClient)
+	var diags diag.Diagnostics
+
+	newDestination := apiclient.DestinationCreate{
+		DestinationDefinitionId: d.Get("destination_definition_id").(string),
+		WorkspaceId:             d.Get("workspace_id").(string),
+		Name:                    d.Get("name").(string),
+	}
+	spec := destinationSpecification(ctx, client, newDestination.DestinationDefinitionId, newDestination.WorkspaceId)
+	newDestination.ConnectionConfiguration = expandConnectionConfiguration(d, spec)
+
+	dest, err := client.CreateDestination(ctx, newDestination)
+	if err != nil {
+		return apiErrorDiags(err)
+	}
+
+	d.SetId(dest.DestinationId)
+
+	resourceDestinationRead(ctx, d, meta)
+
+	return diags
+}
+
+func resourceDestinationRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	c := meta.(*apiclient.ApiClient)
+
+	var diags diag.Diagnostics
+
+	destinationId := d.Id()
+
+	dest, err := c.GetDestinationById(ctx, destinationId)
+	if apiclient.IsNotFound(err) {
+		return removeFromState(d, "Destination")
+	}
+	if err != nil {
+		return apiErrorDiags(err)
+	}
+
+	err = FlattenDestination(d, dest)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func resourceDestinationUpdate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*apiclient.ApiClient)
+	var diags diag.Diagnostics
+
+	spec := destinationSpecification(ctx, client, d.Get("destination_definition_id").(string), d.Get("workspace_id").(string))
+	updatedDestination := apiclient.DestinationUpdate{
+		DestinationId:           d.Get("id").(string),
+		Name:                    d.Get("name").(string),
+		ConnectionConfiguration: expandConnectionConfiguration(d, spec),
+	}
+
+	dest, err := client.UpdateDestination(ctx, updatedDestination)
+	if err != nil {
+		return apiErrorDiags(err)
+	}
+
+	d.SetId(dest.DestinationId)
+
+	resourceDestinationRead(ctx, d, meta)
+
+	return diags
+}
+
+func resourceDestinationDelete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*apiclient.ApiClient)
+	var diags diag.Diagnostics
+
+	destinationId := d.Id()
+
+	err := client.DeleteDestination(ctx, apiclient.DestinationIdRequestBody{DestinationId: destinationId})
+	if err != nil && !apiclient.IsNotFound(err) {
+		return apiErrorDiags(err)
+	}
+
+	return diags
+}
@@ -0,0 +1,200 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/eabrouwer3/terraform-provider-airbyte/internal/apiclient"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAccResourceDestination_basic(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: cassetteProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceDestination_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("airbyte_destination.basic", "name", "basic_test"),
+					resource.TestCheckResourceAttr("airbyte_destination.basic", "destination_name", "Local JSON"),
+					resource.TestCheckResourceAttr("airbyte_destination.basic", "connection_configuration.destination_path", "/local/basic"),
+					resource.TestCheckResourceAttrPair("airbyte_destination.basic", "workspace_id", "airbyte_workspace.basic", "id"),
+				),
+			},
+			{
+				Config: testAccResourceDestination_renamed,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("airbyte_destination.basic", "name", "renamed_test"),
+				),
+			},
+		},
+	})
+}
+
+func TestResourceDestination_deletedOutsideTerraform(t *testing.T) {
+	client := newFakeClient(t)
+	ctx := context.Background()
+
+	w, err := client.CreateWorkspace(ctx, apiclient.WorkspaceCreate{Name: "drift_test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	raw := map[string]any{
+		"name":                      "deleted_test",
+		"destination_definition_id": "a625d593-bba5-4a1c-a53d-2d246268a816",
+		"workspace_id":              w.WorkspaceId,
+		"connection_configuration":  map[string]any{"destination_path": "/local/deleted"},
+	}
+	testDriftRemovesFromState(t, resourceDestination(), raw, client, func(id string) {
+		if err := client.DeleteDestination(ctx, apiclient.DestinationIdRequestBody{DestinationId: id}); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func TestResourceDestination_readKeepsSecrets(t *testing.T) {
+	client := newFakeClient(t)
+	ctx := context.Background()
+
+	w, err := client.CreateWorkspace(ctx, apiclient.WorkspaceCreate{Name: "secrets_test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Postgres marks password as airbyte_secret, so the fake masks it wherever it appears.
+	dest, err := client.CreateDestination(ctx, apiclient.DestinationCreate{
+		DestinationDefinitionId: "25c5221d-dce2-4163-ade9-739ef790f503",
+		WorkspaceId:             w.WorkspaceId,
+		Name:                    "postgres_test",
+		ConnectionConfiguration: map[string]any{
+			"host":          "db.example.com",
+			"port":          5432,
+			"database":      "warehouse",
+			"schema":        "public",
+			"username":      "airbyte",
+			"password":      "hunter2",
+			"ssl":           true,
+			"tunnel_method": map[string]any{"tunnel_method": "SSH_PASSWORD_AUTH", "password": "tunnel-secret"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := resourceDestination()
+	config := map[string]any{
+		"host":          "db.example.com",
+		"port":          "5432",
+		"database":      "warehouse",
+		"schema":        "public",
+		"username":      "airbyte",
+		"password":      "hunter2",
+		"ssl":           "true",
+		"tunnel_method": `{"password":"tunnel-secret","tunnel_method":"SSH_PASSWORD_AUTH"}`,
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]any{
+		"name":                      "postgres_test",
+		"destination_definition_id": "25c5221d-dce2-4163-ade9-739ef790f503",
+		"workspace_id":              w.WorkspaceId,
+		"connection_configuration":  config,
+	})
+	d.SetId(dest.DestinationId)
+
+	if diags := r.ReadContext(ctx, d, client); diags.HasError() {
+		t.Fatalf("unable to read: %#v", diags)
+	}
+	if got := d.Get("connection_configuration").(map[string]any); !reflect.DeepEqual(got, config) {
+		t.Errorf("expected the configuration to read back as %v, got %v", config, got)
+	}
+
+	// Without a known value, like on import, the masked value is all there is.
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]any{})
+	d.SetId(dest.DestinationId)
+	if diags := r.ReadContext(ctx, d, client); diags.HasError() {
+		t.Fatalf("unable to read: %#v", diags)
+	}
+	if got := d.Get("connection_configuration.password"); got != apiclient.RedactedValue {
+		t.Errorf("expected the password to stay masked, got %q", got)
+	}
+}
+
+func TestResourceDestination_createSendsTypedValues(t *testing.T) {
+	client := newFakeClient(t)
+	ctx := context.Background()
+
+	w, err := client.CreateWorkspace(ctx, apiclient.WorkspaceCreate{Name: "types_test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := resourceDestination()
+	config := map[string]any{
+		"host":          "db.example.com",
+		"port":          "5432",
+		"database":      "warehouse",
+		"schema":        "public",
+		"username":      "airbyte",
+		"password":      "hunter2",
+		"ssl":           "true",
+		"tunnel_method": `{"tunnel_method":"NO_TUNNEL"}`,
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]any{
+		"name":                      "postgres_test",
+		"destination_definition_id": "25c5221d-dce2-4163-ade9-739ef790f503",
+		"workspace_id":              w.WorkspaceId,
+		"connection_configuration":  config,
+	})
+	if diags := r.CreateContext(ctx, d, client); diags.HasError() {
+		t.Fatalf("unable to create: %#v", diags)
+	}
+
+	dest, err := client.GetDestinationById(ctx, d.Id())
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]any{
+		"port":          float64(5432),
+		"ssl":           true,
+		"tunnel_method": map[string]any{"tunnel_method": "NO_TUNNEL"},
+		"host":          "db.example.com",
+	}
+	for k, v := range expected {
+		if got := dest.ConnectionConfiguration[k]; !reflect.DeepEqual(got, v) {
+			t.Errorf("expected %s to be stored as %#v, got %#v", k, v, got)
+		}
+	}
+	if got := d.Get("connection_configuration").(map[string]any); !reflect.DeepEqual(got, config) {
+		t.Errorf("expected the configuration to read back as %v, got %v", config, got)
+	}
+}
+
+const testAccResourceDestination_workspace = `
+resource "airbyte_workspace" "basic" {
+  name = "destination_test"
+}
+`
+
+const testAccResourceDestination_basic = testAccResourceDestination_workspace + `
+resource "airbyte_destination" "basic" {
+  name = "basic_test"
+  destination_definition_id = "a625d593-bba5-4a1c-a53d-2d246268a816"
+  workspace_id = airbyte_workspace.basic.id
+  connection_configuration = {
+    destination_path = "/local/basic"
+  }
+}
+`
+
+const testAccResourceDestination_renamed = testAccResourceDestination_workspace + `
+resource "airbyte_destination" "basic" {
+  name = "renamed_test"
+  destination_definition_id = "a625d593-bba5-4a1c-a53d-2d246268a816"
+  workspace_id = airbyte_workspace.basic.id
+  connection_configuration = {
+    destination_path = "/local/basic"
+  }
+}
+`
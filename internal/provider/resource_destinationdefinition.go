@@ -0,0 +1,239 @@
+package provider
+
+import (
+	"context"
+	"github.com/eabrouwer3/terraform-provider-airbyte/internal/apiclient"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"net/http"
+	"time"
+)
+
+func resourceDestinationDefinition() *schema.Resource {
+	return &schema.Resource{
+		// This description is used by the documentation generator and the language server.
+		Description: "Airbyte Destination Definition",
+
+		CreateContext: resourceDestinationDefinitionCreate,
+		ReadContext:   resourceDestinationDefinitionRead,
+		UpdateContext: resourceDestinationDefinitionUpdate,
+		DeleteContext: resourceDestinationDefinitionDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Description: "Destination Definition ID",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"name": {
+				Description: "Destination Definition Name",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"docker_repository": {
+				Description: "Docker Repository URL (e.g. 112233445566.dkr.ecr.us-east-1.amazonaws.com/destination-custom) or DockerHub identifier (e.g. airbyte/destination-postgres)",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"docker_image_tag": {
+				Description: "Docker image tag",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"documentation_url": {
+				Description: "Documentation URL",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"icon": {
+				Description: "URL for the icon displayed in the UI",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"workspace_id": {
+				Description: "If set, the definition is a custom connector only visible in this workspace",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"protocol_version": {
+				Description: "The Airbyte Protocol version supported by the connector",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"release_stage": {
+				Description: "Allowed: alpha | beta | generally_available | custom",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"release_date": {
+				Description: "The date when this connector was first released, in yyyy-mm-dd format",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"supports_dbt": {
+				Description: "Whether the destination can run custom dbt transformations",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"supports_normalization": {
+				Description: "Whether the destination supports basic normalization",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"default_resource_requirements":      defaultResourceRequirementsSchema(),
+			"job_specific_resource_requirements": jobSpecificResourceRequirementsSchema(),
+		},
+	}
+}
+
+func setDestinationDefinitionFields(d *schema.ResourceData) apiclient.DestinationDefinitionCreate {
+	dd := apiclient.DestinationDefinitionCreate{}
+
+	if v, ok := d.GetOk("name"); ok {
+		dd.Name = v.(string)
+	}
+	if v, ok := d.GetOk("docker_repository"); ok {
+		dd.DockerRepository = v.(string)
+	}
+	if v, ok := d.GetOk("docker_image_tag"); ok {
+		dd.DockerImageTag = v.(string)
+	}
+	if v, ok := d.GetOk("documentation_url"); ok {
+		dd.DocumentationUrl = v.(string)
+	}
+	if v, ok := d.GetOk("icon"); ok {
+		dd.Icon = v.(string)
+	}
+
+	_, defaultReqOk := d.GetOk("default_resource_requirements")
+	_, jobSpecReqOk := d.GetOk("job_specific_resource_requirements")
+	if defaultReqOk || jobSpecReqOk {
+		dd.ResourceRequirements = setReqFields(d)
+	}
+
+	return dd
+}
+
+func resourceDestinationDefinitionCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*apiclient.ApiClient)
+	var diags diag.Diagnostics
+
+	newDD := setDestinationDefinitionFields(d)
+
+	var dd *apiclient.DestinationDefinitionRead
+	var err error
+	if workspaceId, ok := d.GetOk("workspace_id"); ok {
+		if err := client.RequireFeature(apiclient.FeatureCustomDefinitions); err != nil {
+			return diag.FromErr(err)
+		}
+		dd, err = client.CreateCustomDestinationDefinition(ctx, apiclient.CustomDestinationDefinitionCreate{
+			WorkspaceId:           workspaceId.(string),
+			DestinationDefinition: newDD,
+		})
+	} else {
+		dd, err = client.CreateDestinationDefinition(ctx, newDD)
+	}
+	if err != nil {
+		if apiclient.StatusCode(err) == http.StatusInternalServerError {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Unable to create destinationDefinition. Airbyte likely unable to find/access specified docker_repository or docker_image_tag.",
+				Detail:   err.Error(),
+			})
+			return diags
+		}
+		return apiErrorDiags(err)
+	}
+
+	d.SetId(dd.DestinationDefinitionId)
+
+	resourceDestinationDefinitionRead(ctx, d, meta)
+
+	return diags
+}
+
+func resourceDestinationDefinitionRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	c := meta.(*apiclient.ApiClient)
+
+	var diags diag.Diagnostics
+
+	ddId := d.Id()
+
+	dd, err := c.GetDestinationDefinitionById(ctx, ddId)
+	if apiclient.IsNotFound(err) {
+		return removeFromState(d, "Destination Definition")
+	}
+	if err != nil {
+		return apiErrorDiags(err)
+	}
+
+	err = FlattenDestinationDefinition(d, dd)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func resourceDestinationDefinitionUpdate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*apiclient.ApiClient)
+	var diags diag.Diagnostics
+
+	updatedDestinationDefinition := apiclient.DestinationDefinitionUpdate{
+		DestinationDefinitionId: d.Get("id").(string),
+		DockerImageTag:          d.Get("docker_image_tag").(string),
+		ResourceRequirements:    nil,
+	}
+	if v, ok := d.GetOk("docker_image_tag"); ok {
+		updatedDestinationDefinition.DockerImageTag = v.(string)
+	}
+	if d.HasChanges("default_resource_requirements", "job_specific_resource_requirements") {
+		updatedDestinationDefinition.ResourceRequirements = setReqFields(d)
+	}
+
+	dd, err := client.UpdateDestinationDefinition(ctx, updatedDestinationDefinition)
+	if err != nil {
+		if apiclient.StatusCode(err) == http.StatusInternalServerError {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Unable to update destinationDefinition. Airbyte likely unable to find/access specified docker_repository or docker_image_tag.",
+				Detail:   err.Error(),
+			})
+			return diags
+		}
+		return apiErrorDiags(err)
+	}
+
+	d.SetId(dd.DestinationDefinitionId)
+
+	resourceDestinationDefinitionRead(ctx, d, meta)
+
+	return diags
+}
+
+func resourceDestinationDefinitionDelete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*apiclient.ApiClient)
+	var diags diag.Diagnostics
+
+	ddId := d.Id()
+
+	err := client.DeleteDestinationDefinition(ctx, apiclient.DestinationDefinitionIdRequestBody{DestinationDefinitionId: ddId})
+	if err != nil && !apiclient.IsNotFound(err) {
+		return apiErrorDiags(err)
+	}
+
+	return diags
+}
@@ -0,0 +1,91 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/eabrouwer3/terraform-provider-airbyte/internal/apiclient"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResourceDestinationDefinition_basic(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: cassetteProviderFactories(t),
+		CheckDestroy:      testAccResourceDestinationDefinitionDestroy(t),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceDestinationDefinition_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("airbyte_destination_definition.basic", "name", "basic_test"),
+					resource.TestCheckResourceAttr("airbyte_destination_definition.basic", "docker_image_tag", "0.1.0"),
+					resource.TestCheckResourceAttr("airbyte_destination_definition.basic", "release_stage", "custom"),
+					resource.TestCheckResourceAttr("airbyte_destination_definition.basic", "job_specific_resource_requirements.#", "1"),
+					resource.TestCheckResourceAttr("airbyte_destination_definition.basic", "job_specific_resource_requirements.0.cpu_limit", "2"),
+					resource.TestCheckResourceAttrPair("data.airbyte_destination_definition.basic", "name", "airbyte_destination_definition.basic", "name"),
+					resource.TestCheckResourceAttr("data.airbyte_destination_definition.basic", "job_specific_resource_requirements.0.cpu_limit", "2"),
+				),
+			},
+			{
+				Config: testAccResourceDestinationDefinition_upgraded,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("airbyte_destination_definition.basic", "docker_image_tag", "0.2.0"),
+					resource.TestCheckResourceAttr("airbyte_destination_definition.basic", "default_resource_requirements.0.memory_request", "1Gi"),
+					resource.TestCheckResourceAttr("airbyte_destination_definition.basic", "job_specific_resource_requirements.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceDestinationDefinitionDestroy(t *testing.T) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccClient(t)
+
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type == "airbyte_destination_definition" {
+				_, err := client.GetDestinationDefinitionById(context.Background(), rs.Primary.ID)
+				if err == nil {
+					return fmt.Errorf("Destination Definition (%s) still exists.", rs.Primary.ID)
+				}
+
+				if !apiclient.IsNotFound(err) {
+					return err
+				}
+			}
+		}
+
+		return nil
+	}
+}
+
+const testAccResourceDestinationDefinition_basic = `
+resource "airbyte_destination_definition" "basic" {
+  name = "basic_test"
+  docker_repository = "example/destination-custom"
+  docker_image_tag = "0.1.0"
+  documentation_url = "https://example.com"
+  job_specific_resource_requirements {
+    job_type = "sync"
+    cpu_limit = "2"
+  }
+}
+
+data "airbyte_destination_definition" "basic" {
+  id = airbyte_destination_definition.basic.id
+}
+`
+
+const testAccResourceDestinationDefinition_upgraded = `
+resource "airbyte_destination_definition" "basic" {
+  name = "basic_test"
+  docker_repository = "example/destination-custom"
+  docker_image_tag = "0.2.0"
+  documentation_url = "https://example.com"
+  default_resource_requirements {
+    memory_request = "1Gi"
+  }
+}
+`
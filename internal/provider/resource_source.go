@@ -3,8 +3,10 @@ package provider
 import (
 	"context"
 	"github.com/eabrouwer3/terraform-provider-airbyte/internal/apiclient"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"time"
 )
 
 func resourceSource() *schema.Resource {
@@ -17,6 +19,13 @@ func resourceSource() *schema.Resource {
 		UpdateContext: resourceSourceUpdate,
 		DeleteContext: resourceSourceDelete,
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Description: "Source ID",
@@ -54,42 +63,52 @@ func resourceSource() *schema.Resource {
 				Description: "Map of Credentials for the source",
 				Type:        schema.TypeMap,
 				Required:    true,
-				Elem:        any,
+				Elem:        &schema.Schema{Type: schema.TypeString},
 			},
 		},
 	}
 }
 
-func setSourceFields(d *schema.ResourceData) apiclient.CommonSourceFields {
-	s := apiclient.CommonSourceFields{}
-
-	if v, ok := d.GetOk("name"); ok {
-		s.Name = v.(string)
-	}
+// connectionConfiguration reads the connection_configuration of a source or destination.
+func connectionConfiguration(d *schema.ResourceData) map[string]any {
 	if v, ok := d.GetOk("connection_configuration"); ok {
-		s.ConnectionConfiguration = v.(map[string]any)
+		return v.(map[string]any)
 	}
+	return map[string]any{}
+}
 
-	return s
+// sourceSpecification fetches the connection specification of the connector, which also gets the
+// fields it marks as secret masked in logs and diagnostics. Failing to fetch it is not fatal: nil is
+// returned, and the configuration is sent as it is written.
+func sourceSpecification(ctx context.Context, client *apiclient.ApiClient, sourceDefinitionId string, workspaceId string) map[string]any {
+	spec, err := client.GetSourceDefinitionSpecification(ctx, apiclient.SourceDefinitionIdWithWorkspaceId{
+		SourceDefinitionId: sourceDefinitionId,
+		WorkspaceId:        workspaceId,
+	})
+	if err != nil {
+		tflog.Warn(ctx, "Unable to fetch the source definition specification, only well-known secret fields will be masked", map[string]any{
+			"error": err.Error(),
+		})
+		return nil
+	}
+	return spec.ConnectionSpecification
 }
 
 func resourceSourceCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
 	client := meta.(*apiclient.ApiClient)
 	var diags diag.Diagnostics
 
-	newSource := apiclient.NewSource{
-		SourceDefinitionIdBody: apiclient.SourceDefinitionIdBody{
-			SourceDefinitionId: d.Get("sourcedefinition_id").(string),
-		},
-		WorkspaceIdBody: apiclient.WorkspaceIdBody{
-			WorkspaceId: d.Get("workspace_id").(string),
-		},
-		CommonSourceFields: setSourceFields(d),
+	newSource := apiclient.SourceCreate{
+		SourceDefinitionId: d.Get("sourcedefinition_id").(string),
+		WorkspaceId:        d.Get("workspace_id").(string),
+		Name:               d.Get("name").(string),
 	}
+	spec := sourceSpecification(ctx, client, newSource.SourceDefinitionId, newSource.WorkspaceId)
+	newSource.ConnectionConfiguration = expandConnectionConfiguration(d, spec)
 
-	s, err := client.CreateSource(newSource)
+	s, err := client.CreateSource(ctx, newSource)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiags(err)
 	}
 
 	d.SetId(s.SourceId)
@@ -106,9 +125,12 @@ func resourceSourceRead(ctx context.Context, d *schema.ResourceData, meta any) d
 
 	sdId := d.Id()
 
-	s, err := c.GetSourceById(sdId)
+	s, err := c.GetSourceById(ctx, sdId)
+	if apiclient.IsNotFound(err) {
+		return removeFromState(d, "Source")
+	}
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiags(err)
 	}
 
 	err = FlattenSource(d, s)
@@ -123,16 +145,16 @@ func resourceSourceUpdate(ctx context.Context, d *schema.ResourceData, meta any)
 	client := meta.(*apiclient.ApiClient)
 	var diags diag.Diagnostics
 
-	updatedSource := apiclient.UpdatedSource{
-		SourceIdBody: apiclient.SourceIdBody{
-			SourceId: d.Get("id").(string),
-		},
-		CommonSourceFields: setSourceFields(d),
+	spec := sourceSpecification(ctx, client, d.Get("sourcedefinition_id").(string), d.Get("workspace_id").(string))
+	updatedSource := apiclient.SourceUpdate{
+		SourceId:                d.Get("id").(string),
+		Name:                    d.Get("name").(string),
+		ConnectionConfiguration: expandConnectionConfiguration(d, spec),
 	}
 
-	s, err := client.UpdateSource(updatedSource)
+	s, err := client.UpdateSource(ctx, updatedSource)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiags(err)
 	}
 
 	d.SetId(s.SourceId)
@@ -148,9 +170,9 @@ func resourceSourceDelete(ctx context.Context, d *schema.ResourceData, meta any)
 
 	sourceId := d.Id()
 
-	err := client.DeleteSource(sourceId)
-	if err != nil {
-		return diag.FromErr(err)
+	err := client.DeleteSource(ctx, apiclient.SourceIdRequestBody{SourceId: sourceId})
+	if err != nil && !apiclient.IsNotFound(err) {
+		return apiErrorDiags(err)
 	}
 
 	return diags
@@ -0,0 +1,30 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/eabrouwer3/terraform-provider-airbyte/internal/apiclient"
+)
+
+func TestResourceSource_deletedOutsideTerraform(t *testing.T) {
+	client := newFakeClient(t)
+	ctx := context.Background()
+
+	w, err := client.CreateWorkspace(ctx, apiclient.WorkspaceCreate{Name: "drift_test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	raw := map[string]any{
+		"name":                     "deleted_test",
+		"sourcedefinition_id":      "dfd88b22-b603-4c3d-aad7-3701784586b1",
+		"workspace_id":             w.WorkspaceId,
+		"connection_configuration": map[string]any{"count": "10"},
+	}
+	testDriftRemovesFromState(t, resourceSource(), raw, client, func(id string) {
+		if err := client.DeleteSource(ctx, apiclient.SourceIdRequestBody{SourceId: id}); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
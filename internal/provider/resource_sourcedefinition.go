@@ -5,8 +5,8 @@ import (
 	"github.com/eabrouwer3/terraform-provider-airbyte/internal/apiclient"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-	"strings"
+	"net/http"
+	"time"
 )
 
 func resourceSourceDefinition() *schema.Resource {
@@ -19,6 +19,13 @@ func resourceSourceDefinition() *schema.Resource {
 		UpdateContext: resourceSourceDefinitionUpdate,
 		DeleteContext: resourceSourceDefinitionDelete,
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Description: "Source Definition ID",
@@ -55,6 +62,12 @@ func resourceSourceDefinition() *schema.Resource {
 				Computed:    true,
 				ForceNew:    true,
 			},
+			"workspace_id": {
+				Description: "If set, the definition is a custom connector only visible in this workspace",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+			},
 			"protocol_version": {
 				Description: "The Airbyte Protocol version supported by the connector",
 				Type:        schema.TypeString,
@@ -75,87 +88,14 @@ func resourceSourceDefinition() *schema.Resource {
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
-			"default_resource_requirements": {
-				Description: "Actor definition specific resource requirements. Ff default is set, these are the requirements " +
-					"that should be set for ALL jobs run for this actor definition. It is overridden by the job type specific " +
-					"configurations. If not set, the platform will use defaults. These values will be overridden by configuration " +
-					"at the connection level.",
-				Type:     schema.TypeList,
-				MaxItems: 1,
-				Optional: true,
-				Computed: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"cpu_request": {
-							Description: "CPU Requested",
-							Type:        schema.TypeString,
-							Optional:    true,
-							Computed:    true,
-						},
-						"cpu_limit": {
-							Description: "CPU Limit",
-							Type:        schema.TypeString,
-							Optional:    false,
-							Computed:    true,
-						},
-						"memory_request": {
-							Description: "Memory Requested",
-							Type:        schema.TypeString,
-							Optional:    true,
-							Computed:    true,
-						},
-						"memory_limit": {
-							Description: "Memory Limit",
-							Type:        schema.TypeString,
-							Optional:    true,
-							Computed:    true,
-						},
-					},
-				},
-			},
-			"job_specific_resource_requirements": {
-				Description: "Sets resource requirements for a specific job type for an actor definition. These values override " +
-					"the default, if both are set. These values will be overridden by configuration at the connection level.",
-				Type:     schema.TypeList,
-				Optional: true,
-				Computed: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"job_type": {
-							Description:  "Allowed: get_spec | check_connection | discover_schema | sync | reset_connection | connection_updater | replicate",
-							Type:         schema.TypeString,
-							Required:     true,
-							ValidateFunc: validation.StringInSlice([]string{"get_spec", "check_connection", "discover_schema", "sync", "reset_connection", "connection_updater", "replicate"}, false),
-						},
-						"cpu_request": {
-							Description: "CPU Requested",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
-						"cpu_limit": {
-							Description: "CPU Limit",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
-						"memory_request": {
-							Description: "Memory Requested",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
-						"memory_limit": {
-							Description: "Memory Limit",
-							Type:        schema.TypeString,
-							Optional:    true,
-						},
-					},
-				},
-			},
+			"default_resource_requirements":      defaultResourceRequirementsSchema(),
+			"job_specific_resource_requirements": jobSpecificResourceRequirementsSchema(),
 		},
 	}
 }
 
-func setSourceDefinitionFields(d *schema.ResourceData) apiclient.CommonSourceDefinitionFields {
-	sd := apiclient.CommonSourceDefinitionFields{}
+func setSourceDefinitionFields(d *schema.ResourceData) apiclient.SourceDefinitionCreate {
+	sd := apiclient.SourceDefinitionCreate{}
 
 	if v, ok := d.GetOk("name"); ok {
 		sd.Name = v.(string)
@@ -182,67 +122,27 @@ func setSourceDefinitionFields(d *schema.ResourceData) apiclient.CommonSourceDef
 	return sd
 }
 
-func setReqFields(d *schema.ResourceData) *apiclient.ResourceRequirements {
-	reqs := apiclient.ResourceRequirements{}
-
-	defaultReqs, defaultReqOk := d.GetOk("default_resource_requirements")
-	if defaultReqOk && len(defaultReqs.([]interface{})) > 0 {
-		defaultReq := defaultReqs.([]map[string]interface{})[0]
-		if v := defaultReq["cpu_request"].(string); v != "" {
-			reqs.Default.CPURequest = v
-		}
-		if v := defaultReq["cpu_limit"].(string); v != "" {
-			reqs.Default.CPULimit = v
-		}
-		if v := defaultReq["memory_request"].(string); v != "" {
-			reqs.Default.MemoryRequest = v
-		}
-		if v := defaultReq["memory_limit"].(string); v != "" {
-			reqs.Default.MemoryLimit = v
-		}
-	}
-
-	jobSpecInput, jobSpecOk := d.GetOk("job_specific_resource_requirements")
-	if jobSpecOk {
-		var jobSpecReqs []apiclient.JobSpecificResourceRequirements
-
-		for _, rawJobSpec := range jobSpecInput.([]interface{}) {
-			rjs := rawJobSpec.(map[string]interface{})
-
-			js := apiclient.JobSpecificResourceRequirements{
-				JobType: rjs["job_type"].(string),
-			}
-
-			if v := rjs["cpu_request"].(string); v != "" {
-				js.ResourceRequirements.CPURequest = v
-			}
-			if v := rjs["cpu_limit"].(string); v != "" {
-				js.ResourceRequirements.CPULimit = v
-			}
-			if v := rjs["memory_request"].(string); v != "" {
-				js.ResourceRequirements.MemoryRequest = v
-			}
-			if v := rjs["memory_limit"].(string); v != "" {
-				js.ResourceRequirements.MemoryLimit = v
-			}
-
-			jobSpecReqs = append(jobSpecReqs, js)
-		}
-		reqs.JobSpecific = &jobSpecReqs
-	}
-
-	return &reqs
-}
-
 func resourceSourceDefinitionCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
 	client := meta.(*apiclient.ApiClient)
 	var diags diag.Diagnostics
 
 	newSD := setSourceDefinitionFields(d)
 
-	sd, err := client.CreateSourceDefinition(newSD)
+	var sd *apiclient.SourceDefinitionRead
+	var err error
+	if workspaceId, ok := d.GetOk("workspace_id"); ok {
+		if err := client.RequireFeature(apiclient.FeatureCustomDefinitions); err != nil {
+			return diag.FromErr(err)
+		}
+		sd, err = client.CreateCustomSourceDefinition(ctx, apiclient.CustomSourceDefinitionCreate{
+			WorkspaceId:      workspaceId.(string),
+			SourceDefinition: newSD,
+		})
+	} else {
+		sd, err = client.CreateSourceDefinition(ctx, newSD)
+	}
 	if err != nil {
-		if strings.Contains(err.Error(), "status: 500") {
+		if apiclient.StatusCode(err) == http.StatusInternalServerError {
 			diags = append(diags, diag.Diagnostic{
 				Severity: diag.Error,
 				Summary:  "Unable to create sourceDefinition. Airbyte likely unable to find/access specified docker_repository or docker_image_tag.",
@@ -250,7 +150,7 @@ func resourceSourceDefinitionCreate(ctx context.Context, d *schema.ResourceData,
 			})
 			return diags
 		}
-		return diag.FromErr(err)
+		return apiErrorDiags(err)
 	}
 
 	d.SetId(sd.SourceDefinitionId)
@@ -267,9 +167,12 @@ func resourceSourceDefinitionRead(ctx context.Context, d *schema.ResourceData, m
 
 	sdId := d.Id()
 
-	sd, err := c.GetSourceDefinitionById(sdId)
+	sd, err := c.GetSourceDefinitionById(ctx, sdId)
+	if apiclient.IsNotFound(err) {
+		return removeFromState(d, "Source Definition")
+	}
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiags(err)
 	}
 
 	err = FlattenSourceDefinition(d, sd)
@@ -284,33 +187,29 @@ func resourceSourceDefinitionUpdate(ctx context.Context, d *schema.ResourceData,
 	client := meta.(*apiclient.ApiClient)
 	var diags diag.Diagnostics
 
-	updatedSourceDefinition := apiclient.UpdatedSourceDefinition{
-		SourceDefinitionIdBody: apiclient.SourceDefinitionIdBody{
-			SourceDefinitionId: d.Get("id").(string),
-		},
+	updatedSourceDefinition := apiclient.SourceDefinitionUpdate{
+		SourceDefinitionId:   d.Get("id").(string),
 		DockerImageTag:       d.Get("docker_image_tag").(string),
 		ResourceRequirements: nil,
 	}
 	if v, ok := d.GetOk("docker_image_tag"); ok {
 		updatedSourceDefinition.DockerImageTag = v.(string)
 	}
-	_, defaultReqOk := d.GetOk("default_resource_requirements")
-	_, jobSpecReqOk := d.GetOk("job_specific_resource_requirements")
-	if defaultReqOk || jobSpecReqOk {
+	if d.HasChanges("default_resource_requirements", "job_specific_resource_requirements") {
 		updatedSourceDefinition.ResourceRequirements = setReqFields(d)
 	}
 
-	sd, err := client.UpdateSourceDefinition(updatedSourceDefinition)
+	sd, err := client.UpdateSourceDefinition(ctx, updatedSourceDefinition)
 	if err != nil {
-		if strings.Contains(err.Error(), "status: 500") {
+		if apiclient.StatusCode(err) == http.StatusInternalServerError {
 			diags = append(diags, diag.Diagnostic{
 				Severity: diag.Error,
-				Summary:  "Unable to create sourceDefinition. Airbyte likely unable to find/access specified docker_repository or docker_image_tag.",
+				Summary:  "Unable to update sourceDefinition. Airbyte likely unable to find/access specified docker_repository or docker_image_tag.",
 				Detail:   err.Error(),
 			})
 			return diags
 		}
-		return diag.FromErr(err)
+		return apiErrorDiags(err)
 	}
 
 	d.SetId(sd.SourceDefinitionId)
@@ -326,9 +225,9 @@ func resourceSourceDefinitionDelete(ctx context.Context, d *schema.ResourceData,
 
 	sdId := d.Id()
 
-	err := client.DeleteSourceDefinition(sdId)
-	if err != nil {
-		return diag.FromErr(err)
+	err := client.DeleteSourceDefinition(ctx, apiclient.SourceDefinitionIdRequestBody{SourceDefinitionId: sdId})
+	if err != nil && !apiclient.IsNotFound(err) {
+		return apiErrorDiags(err)
 	}
 
 	return diags
@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/eabrouwer3/terraform-provider-airbyte/internal/apiclient"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceSourceDefinition_resourceRequirements(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: cassetteProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceSourceDefinition_requirements,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("airbyte_sourcedefinition.reqs", "default_resource_requirements.#", "1"),
+					resource.TestCheckResourceAttr("airbyte_sourcedefinition.reqs", "default_resource_requirements.0.cpu_request", "0.5"),
+					resource.TestCheckResourceAttr("airbyte_sourcedefinition.reqs", "default_resource_requirements.0.cpu_limit", "1"),
+					resource.TestCheckResourceAttr("airbyte_sourcedefinition.reqs", "job_specific_resource_requirements.#", "1"),
+					resource.TestCheckResourceAttr("airbyte_sourcedefinition.reqs", "job_specific_resource_requirements.0.job_type", "sync"),
+					resource.TestCheckResourceAttr("airbyte_sourcedefinition.reqs", "job_specific_resource_requirements.0.memory_limit", "2Gi"),
+					resource.TestCheckResourceAttr("data.airbyte_sourcedefinition.reqs", "default_resource_requirements.0.cpu_limit", "1"),
+					resource.TestCheckResourceAttr("data.airbyte_sourcedefinition.reqs", "job_specific_resource_requirements.0.memory_limit", "2Gi"),
+				),
+			},
+			{
+				Config: testAccResourceSourceDefinition_noRequirements,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("airbyte_sourcedefinition.reqs", "default_resource_requirements.#", "0"),
+					resource.TestCheckResourceAttr("airbyte_sourcedefinition.reqs", "job_specific_resource_requirements.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestResourceSourceDefinition_deletedOutsideTerraform(t *testing.T) {
+	client := newFakeClient(t)
+
+	raw := map[string]any{
+		"name":              "deleted_test",
+		"docker_repository": "airbyte/source-faker",
+		"docker_image_tag":  "0.1.0",
+		"documentation_url": "https://docs.airbyte.com/integrations/sources/faker",
+	}
+	testDriftRemovesFromState(t, resourceSourceDefinition(), raw, client, func(id string) {
+		err := client.DeleteSourceDefinition(context.Background(), apiclient.SourceDefinitionIdRequestBody{SourceDefinitionId: id})
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+const testAccResourceSourceDefinition_requirements = `
+resource "airbyte_sourcedefinition" "reqs" {
+  name = "reqs_test"
+  docker_repository = "airbyte/source-faker"
+  docker_image_tag = "0.1.0"
+  documentation_url = "https://docs.airbyte.com/integrations/sources/faker"
+  default_resource_requirements {
+    cpu_request = "0.5"
+    cpu_limit = "1"
+  }
+  job_specific_resource_requirements {
+    job_type = "sync"
+    memory_limit = "2Gi"
+  }
+}
+
+data "airbyte_sourcedefinition" "reqs" {
+  id = airbyte_sourcedefinition.reqs.id
+}
+`
+
+const testAccResourceSourceDefinition_noRequirements = `
+resource "airbyte_sourcedefinition" "reqs" {
+  name = "reqs_test"
+  docker_repository = "airbyte/source-faker"
+  docker_image_tag = "0.1.0"
+  documentation_url = "https://docs.airbyte.com/integrations/sources/faker"
+}
+`
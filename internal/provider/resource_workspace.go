@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/eabrouwer3/terraform-provider-airbyte/internal/apiclient"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -19,6 +20,15 @@ func resourceWorkspace() *schema.Resource {
 		UpdateContext: resourceWorkspaceUpdate,
 		DeleteContext: resourceWorkspaceDelete,
 
+		CustomizeDiff: resourceWorkspaceCustomizeDiff,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Description: "Workspace ID",
@@ -31,10 +41,9 @@ func resourceWorkspace() *schema.Resource {
 				Computed:    true,
 			},
 			"email": {
-				Description: "Customer Email",
+				Description: "Customer Email. Removing it clears the email of the workspace",
 				Type:        schema.TypeString,
 				Optional:    true,
-				Computed:    true,
 			},
 			"name": {
 				Description: "Workspace Name",
@@ -80,7 +89,6 @@ func resourceWorkspace() *schema.Resource {
 				Description: "Notification systems set up",
 				Type:        schema.TypeList,
 				Optional:    true,
-				Computed:    true,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"notification_type": {
@@ -120,75 +128,115 @@ func resourceWorkspace() *schema.Resource {
 				Computed:    true,
 			},
 			"default_geography": {
-				Description: "Possible values: auto | us | eu",
-				Type:        schema.TypeString,
-				Computed:    true,
+				Description:  "Possible values: auto | us | eu",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"auto", "us", "eu"}, false),
 			},
 		},
 	}
 }
 
-func resourceToWorkspace(d *schema.ResourceData) apiclient.CommonWorkspaceFields {
-	workspace := apiclient.CommonWorkspaceFields{}
-
-	if v, ok := d.GetOk("email"); ok {
-		workspace.Email = v.(string)
+// resourceToWorkspace reads the attributes workspaces/create and workspaces/update have in common.
+// Booleans are only sent when they are set in the configuration, so that false turns a setting off
+// and unset leaves the server's value alone. The email and notifications are always sent, so that
+// removing the email or every notification_config block clears them.
+func resourceToWorkspace(d *schema.ResourceData, client *apiclient.ApiClient) apiclient.WorkspaceUpdate {
+	workspace := apiclient.WorkspaceUpdate{
+		AnonymousDataCollection: configuredBool(d, "anonymous_data_collection"),
+		News:                    configuredBool(d, "news"),
+		SecurityUpdates:         configuredBool(d, "security_updates"),
+		DisplaySetupWizard:      configuredBool(d, "display_setup_wizard"),
 	}
-	if v, ok := d.GetOk("anonymous_data_collection"); ok {
-		val := v.(bool)
-		workspace.AnonymousDataCollection = &val
-	}
-	if v, ok := d.GetOk("news"); ok {
-		val := v.(bool)
-		workspace.News = &val
+
+	email := d.Get("email").(string)
+	workspace.Email = &email
+	// Servers from before geographies don't know the field, so a value kept in the state isn't sent.
+	if v, ok := d.GetOk("default_geography"); ok && client.Supports(apiclient.FeatureDefaultGeography) {
+		workspace.DefaultGeography = apiclient.Geography(v.(string))
 	}
-	if v, ok := d.GetOk("security_updates"); ok {
-		val := v.(bool)
-		workspace.SecurityUpdates = &val
+
+	notifs := []apiclient.Notification{}
+	for _, rawNotif := range d.Get("notification_config").([]interface{}) {
+		rn := rawNotif.(map[string]interface{})
+
+		n := apiclient.Notification{
+			NotificationType: apiclient.NotificationType(rn["notification_type"].(string)),
+			SendOnSuccess:    rn["send_on_success"].(bool),
+			SendOnFailure:    rn["send_on_failure"].(bool),
+			SlackConfiguration: &apiclient.SlackNotificationConfiguration{
+				Webhook: rn["slack_webhook"].(string),
+			},
+		}
+
+		notifs = append(notifs, n)
 	}
-	if v, ok := d.GetOk("display_setup_wizard"); ok {
-		val := v.(bool)
-		workspace.DisplaySetupWizard = &val
+	workspace.Notifications = notifs
+
+	return workspace
+}
+
+// configuredBool returns the value of a boolean attribute if the configuration sets it, and nil
+// otherwise. d.GetOk can't be used for this, as it reports false as unset. Without a configuration,
+// as when the data isn't built from a plan, the current value is returned.
+func configuredBool(d *schema.ResourceData, key string) *bool {
+	raw := d.GetRawConfig()
+	if raw.IsNull() || !raw.IsKnown() {
+		v := d.Get(key).(bool)
+		return &v
 	}
 
-	notifInput, notifOk := d.GetOk("notification_config")
-	if notifOk {
-		var notifs []apiclient.Notification
+	attr := raw.GetAttr(key)
+	if attr.IsNull() || !attr.IsKnown() {
+		return nil
+	}
+	v := attr.True()
+	return &v
+}
 
-		for _, rawNotif := range notifInput.([]interface{}) {
-			rn := rawNotif.(map[string]interface{})
+// resourceWorkspaceCustomizeDiff fails the plan when the configuration uses attributes the server is
+// too old for.
+func resourceWorkspaceCustomizeDiff(_ context.Context, d *schema.ResourceDiff, meta any) error {
+	client := meta.(*apiclient.ApiClient)
 
-			n := apiclient.Notification{
-				NotificationType: rn["notification_type"].(string),
-				SendOnSuccess:    rn["send_on_success"].(bool),
-				SendOnFailure:    rn["send_on_failure"].(bool),
-				SlackConfiguration: apiclient.SlackConfiguration{
-					Webhook: rn["slack_webhook"].(string),
-				},
+	if _, ok := d.GetOk("default_geography"); ok && d.HasChange("default_geography") {
+		if err := client.RequireFeature(apiclient.FeatureDefaultGeography); err != nil {
+			return err
+		}
+	}
+	if d.HasChange("notification_config") {
+		for _, rawNotif := range d.Get("notification_config").([]interface{}) {
+			if rawNotif == nil || !rawNotif.(map[string]interface{})["send_on_success"].(bool) {
+				continue
+			}
+			if err := client.RequireFeature(apiclient.FeatureSuccessNotifications); err != nil {
+				return err
 			}
-
-			notifs = append(notifs, n)
 		}
-		workspace.Notifications = notifs
 	}
-
-	return workspace
+	return nil
 }
 
 func resourceWorkspaceCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
 	client := meta.(*apiclient.ApiClient)
 	var diags diag.Diagnostics
 
-	newWorkspace := apiclient.NewWorkspace{
-		WorkspaceNameBody: apiclient.WorkspaceNameBody{
-			Name: d.Get("name").(string),
-		},
-		CommonWorkspaceFields: resourceToWorkspace(d),
+	fields := resourceToWorkspace(d, client)
+	newWorkspace := apiclient.WorkspaceCreate{
+		Name:                    d.Get("name").(string),
+		Email:                   *fields.Email,
+		AnonymousDataCollection: fields.AnonymousDataCollection,
+		News:                    fields.News,
+		SecurityUpdates:         fields.SecurityUpdates,
+		DisplaySetupWizard:      fields.DisplaySetupWizard,
+		Notifications:           fields.Notifications,
+		DefaultGeography:        fields.DefaultGeography,
 	}
 
-	w, err := client.CreateWorkspace(newWorkspace)
+	w, err := client.CreateWorkspace(ctx, newWorkspace)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiags(err)
 	}
 
 	d.SetId(w.WorkspaceId)
@@ -206,9 +254,12 @@ func resourceWorkspaceRead(ctx context.Context, d *schema.ResourceData, meta any
 
 	workspaceId := d.Id()
 
-	w, err := c.GetWorkspaceById(workspaceId)
+	w, err := c.GetWorkspaceById(ctx, workspaceId)
+	if apiclient.IsNotFound(err) || (err == nil && w.IsTombstoned()) {
+		return removeFromState(d, "Workspace")
+	}
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiags(err)
 	}
 
 	err = FlattenWorkspace(d, w)
@@ -223,16 +274,12 @@ func resourceWorkspaceUpdate(ctx context.Context, d *schema.ResourceData, meta a
 	client := meta.(*apiclient.ApiClient)
 	var diags diag.Diagnostics
 
-	updatedWorkspace := apiclient.UpdatedWorkspace{
-		WorkspaceIdBody: apiclient.WorkspaceIdBody{
-			WorkspaceId: d.Get("id").(string),
-		},
-		CommonWorkspaceFields: resourceToWorkspace(d),
-	}
+	updatedWorkspace := resourceToWorkspace(d, client)
+	updatedWorkspace.WorkspaceId = d.Get("id").(string)
 
-	w, err := client.UpdateWorkspace(updatedWorkspace)
+	w, err := client.UpdateWorkspace(ctx, updatedWorkspace)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiags(err)
 	}
 
 	d.SetId(w.WorkspaceId)
@@ -248,9 +295,9 @@ func resourceWorkspaceDelete(ctx context.Context, d *schema.ResourceData, meta a
 
 	workspaceId := d.Id()
 
-	err := client.DeleteWorkspace(workspaceId)
-	if err != nil {
-		return diag.FromErr(err)
+	err := client.DeleteWorkspace(ctx, apiclient.WorkspaceIdRequestBody{WorkspaceId: workspaceId})
+	if err != nil && !apiclient.IsNotFound(err) {
+		return apiErrorDiags(err)
 	}
 
 	return diags
@@ -1,6 +1,7 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"github.com/eabrouwer3/terraform-provider-airbyte/internal/apiclient"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -8,14 +9,16 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func TestAccResourceWorkspace_basic(t *testing.T) {
 	resource.UnitTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
-		CheckDestroy:      testAccResourceWorkspaceDestroy,
+		ProviderFactories: cassetteProviderFactories(t),
+		CheckDestroy:      testAccResourceWorkspaceDestroy(t),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccResourceWorkspace_basic,
@@ -33,7 +36,7 @@ func TestAccResourceWorkspace_basic(t *testing.T) {
 func TestAccResourceWorkspace_complex(t *testing.T) {
 	resource.UnitTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
+		ProviderFactories: cassetteProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccResourceWorkspace_complex,
@@ -63,27 +66,124 @@ func TestAccResourceWorkspace_complex(t *testing.T) {
 					resource.TestCheckResourceAttr("airbyte_workspace.complex", "notification_config.#", "1"),
 				),
 			},
+			{
+				Config: testAccResourceWorkspace_complexOff,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("airbyte_workspace.complex", "email", ""),
+					resource.TestCheckResourceAttr("airbyte_workspace.complex", "display_setup_wizard", "false"),
+					resource.TestCheckResourceAttr("airbyte_workspace.complex", "anonymous_data_collection", "false"),
+					resource.TestCheckResourceAttr("airbyte_workspace.complex", "news", "false"),
+					resource.TestCheckResourceAttr("airbyte_workspace.complex", "security_updates", "false"),
+					resource.TestCheckResourceAttr("airbyte_workspace.complex", "notification_config.#", "0"),
+				),
+			},
 		},
 	})
 }
 
-func testAccResourceWorkspaceDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*apiclient.ApiClient)
+func TestResourceWorkspace_tombstoned(t *testing.T) {
+	client := newFakeClient(t)
 
-	for _, rs := range s.RootModule().Resources {
-		if rs.Type == "airbyte_workspace" {
-			_, err := client.GetWorkspaceById(rs.Primary.ID)
-			if err == nil {
-				return fmt.Errorf("Workspace (%s) still exists.", rs.Primary.ID)
-			}
+	testDriftRemovesFromState(t, resourceWorkspace(), map[string]any{"name": "tombstoned_test"}, client, func(id string) {
+		if err := client.DeleteWorkspace(context.Background(), apiclient.WorkspaceIdRequestBody{WorkspaceId: id}); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
 
-			if !strings.Contains(err.Error(), "Could not find configuration for STANDARD_WORKSPACE") {
-				return err
-			}
+func TestResourceWorkspace_removeEmail(t *testing.T) {
+	client := newFakeClient(t)
+	ctx := context.Background()
+	r := resourceWorkspace()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]any{"name": "email_test", "email": "test@example.com"})
+	if diags := r.CreateContext(ctx, d, client); diags.HasError() {
+		t.Fatalf("unable to create: %#v", diags)
+	}
+	id := d.Id()
+
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]any{"name": "email_test"})
+	d.SetId(id)
+	if diags := r.UpdateContext(ctx, d, client); diags.HasError() {
+		t.Fatalf("unable to update: %#v", diags)
+	}
+
+	w, err := client.GetWorkspaceById(ctx, d.Id())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w.Email != "" {
+		t.Errorf("expected the email to be cleared, got %q", w.Email)
+	}
+}
+
+func TestResourceToWorkspace_serverVersion(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceWorkspace().Schema, map[string]any{"name": "geo", "default_geography": "eu"})
+
+	cases := map[string]apiclient.Geography{
+		"":        apiclient.GeographyEu,
+		"0.50.33": apiclient.GeographyEu,
+		"0.40.3":  "",
+	}
+	for v, expected := range cases {
+		client := &apiclient.ApiClient{}
+		if v != "" {
+			client.ServerVersion = version.Must(version.NewVersion(v))
+		}
+		if got := resourceToWorkspace(d, client).DefaultGeography; got != expected {
+			t.Errorf("%q: expected default geography %q, got %q", v, expected, got)
+		}
+	}
+}
+
+func TestResourceWorkspace_planRequiresFeatures(t *testing.T) {
+	ctx := context.Background()
+	r := resourceWorkspace()
+	client := &apiclient.ApiClient{ServerVersion: version.Must(version.NewVersion("0.29.0")), RawServerVersion: "0.29.0"}
+
+	cases := map[string]map[string]any{
+		"default geography": {"name": "geo", "default_geography": "eu"},
+		"notifications on successful syncs": {"name": "notif", "notification_config": []any{map[string]any{
+			"notification_type": "slack",
+			"send_on_success":   true,
+			"slack_webhook":     "https://hooks.slack.com/services/T/B/X",
+		}}},
+	}
+	for name, raw := range cases {
+		_, err := r.Diff(ctx, nil, terraform.NewResourceConfigRaw(raw), client)
+		if err == nil || !strings.Contains(err.Error(), "requires Airbyte >=") {
+			t.Errorf("%s: expected the plan to fail, got %v", name, err)
 		}
 	}
 
-	return nil
+	raw := map[string]any{"name": "notif", "notification_config": []any{map[string]any{
+		"notification_type": "slack",
+		"slack_webhook":     "https://hooks.slack.com/services/T/B/X",
+	}}}
+	if _, err := r.Diff(ctx, nil, terraform.NewResourceConfigRaw(raw), client); err != nil {
+		t.Errorf("expected failure notifications to plan, got %v", err)
+	}
+}
+
+func testAccResourceWorkspaceDestroy(t *testing.T) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccClient(t)
+
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type == "airbyte_workspace" {
+				w, err := client.GetWorkspaceById(context.Background(), rs.Primary.ID)
+				if err == nil && !w.IsTombstoned() {
+					return fmt.Errorf("Workspace (%s) still exists.", rs.Primary.ID)
+				}
+
+				if err != nil && !apiclient.IsNotFound(err) {
+					return err
+				}
+			}
+		}
+
+		return nil
+	}
 }
 
 const testAccResourceWorkspace_basic = `
@@ -128,3 +228,13 @@ resource "airbyte_workspace" "complex" {
   }
 }
 `
+
+const testAccResourceWorkspace_complexOff = `
+resource "airbyte_workspace" "complex" {
+  name = "complex_test"
+  display_setup_wizard = false
+  anonymous_data_collection = false
+  news = false
+  security_updates = false
+}
+`